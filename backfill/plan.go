@@ -0,0 +1,270 @@
+// Package backfill plans and tracks the processing of historical partitions: an Interval is split into work units,
+// one per Duration, whose status is persisted to a Store, so that processing resumes where it stopped after a crash.
+package backfill
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/daneroo/timewalker"
+)
+
+// Status is the processing status of a Unit
+type Status int
+
+// Different package constants defining an enum type for Status
+const (
+	Pending Status = iota
+	Running
+	Done
+	Failed
+)
+
+// Produces Human readable representations of the Status enum values
+func (s Status) String() string {
+	str := "Invalid"
+	switch s {
+	case Pending:
+		str = "Pending"
+	case Running:
+		str = "Running"
+	case Done:
+		str = "Done"
+	case Failed:
+		str = "Failed"
+	}
+	return str
+}
+
+// MarshalText implements encoding.TextMarshaler, a Status is serialized as its name
+func (s Status) MarshalText() ([]byte, error) {
+	if s < Pending || s > Failed {
+		return nil, fmt.Errorf("invalid Status: %d", int(s))
+	}
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, the match is case-insensitive
+func (s *Status) UnmarshalText(text []byte) error {
+	for _, status := range []Status{Pending, Running, Done, Failed} {
+		if strings.EqualFold(string(text), status.String()) {
+			*s = status
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid Status: %q", text)
+}
+
+// Unit is the work unit of a single partition
+type Unit struct {
+	Interval timewalker.Interval `json:"interval"`
+	Status   Status              `json:"status"`
+	// Attempts counts the times the Unit was started
+	Attempts int `json:"attempts"`
+	// Error is the error of the last failed attempt
+	Error string `json:"error,omitempty"`
+}
+
+// Plan is the list of Units covering an Interval, in order, one per Duration
+type Plan struct {
+	Interval timewalker.Interval `json:"interval"`
+	Duration timewalker.Duration `json:"duration"`
+	Units    []Unit              `json:"units"`
+}
+
+// NewPlan returns a Plan of Pending Units covering the receiver's interval, rounded to the Duration, see timewalker.Interval.Round
+func NewPlan(i timewalker.Interval, d timewalker.Duration) (*Plan, error) {
+	p := &Plan{Duration: d}
+	if _, err := p.cover(i); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// Count returns the number of Units with the given Status
+func (p Plan) Count(s Status) int {
+	count := 0
+	for _, u := range p.Units {
+		if u.Status == s {
+			count++
+		}
+	}
+	return count
+}
+
+// cover adds Pending Units so that the Plan covers i, keeping the existing Units, and returns the number of Units added
+func (p *Plan) cover(i timewalker.Interval) (int, error) {
+	if i.Bounds&(timewalker.StartUnbounded|timewalker.EndUnbounded) != 0 {
+		return 0, fmt.Errorf("cannot plan an unbounded Interval: %v", i)
+	}
+	ch, err := i.Walk(p.Duration)
+	if err != nil {
+		return 0, err
+	}
+	planned := make(map[int64]bool, len(p.Units))
+	for _, u := range p.Units {
+		planned[u.Interval.Start.UnixNano()] = true
+	}
+	added := 0
+	for i := range ch {
+		if !planned[i.Start.UnixNano()] {
+			p.Units = append(p.Units, Unit{Interval: i})
+			added++
+		}
+	}
+	sort.Slice(p.Units, func(a, b int) bool { return p.Units[a].Interval.Start.Before(p.Units[b].Interval.Start) })
+	if len(p.Units) > 0 {
+		p.Interval = timewalker.Interval{Start: p.Units[0].Interval.Start, End: p.Units[len(p.Units)-1].Interval.End}
+	}
+	return added, nil
+}
+
+// find returns the index of the Unit starting at start
+func (p *Plan) find(start time.Time) (int, error) {
+	k := sort.Search(len(p.Units), func(k int) bool { return !p.Units[k].Interval.Start.Before(start) })
+	if k == len(p.Units) || !p.Units[k].Interval.Start.Equal(start) {
+		return 0, fmt.Errorf("no Unit starting at %s in %v", start.Format(time.RFC3339), p.Interval)
+	}
+	return k, nil
+}
+
+// Planner hands out the Units of a Plan and tracks their Status, saving the Plan to its Store after every change.
+// It is safe for concurrent use.
+type Planner struct {
+	store Store
+	loc   *time.Location
+	mu    sync.Mutex
+	plan  *Plan
+}
+
+// Open returns a Planner for the Plan covering i by d, resuming the Plan saved in store, if any.
+// Opening is idempotent: Units of a saved Plan keep their Status, and Units are only added for the part of i not covered yet.
+// Units left Running, by a crash, are reset to Pending.
+// The Plan is extended in the Location of i.Start: a saved Plan may come back in a fixed zone, e.g. when it was built in time.Local.
+func Open(store Store, i timewalker.Interval, d timewalker.Duration) (*Planner, error) {
+	plan, err := store.Load()
+	if err != nil {
+		return nil, err
+	}
+	if plan == nil {
+		plan = &Plan{Duration: d}
+	} else if plan.Duration != d {
+		return nil, fmt.Errorf("saved Plan is by %v, not by %v", plan.Duration, d)
+	}
+	if _, err := plan.cover(i); err != nil {
+		return nil, err
+	}
+	for k := range plan.Units {
+		if plan.Units[k].Status == Running {
+			plan.Units[k].Status = Pending
+		}
+	}
+	if err := store.Save(plan); err != nil {
+		return nil, err
+	}
+	return &Planner{store: store, loc: i.Start.Location(), plan: plan}, nil
+}
+
+// Plan returns a copy of the current Plan
+func (pl *Planner) Plan() Plan {
+	pl.mu.Lock()
+	defer pl.mu.Unlock()
+	plan := *pl.plan
+	plan.Units = append([]Unit(nil), pl.plan.Units...)
+	return plan
+}
+
+// Next marks the earliest Pending Unit as Running, and returns it. It returns false when no Unit is Pending.
+func (pl *Planner) Next() (Unit, bool, error) {
+	pl.mu.Lock()
+	defer pl.mu.Unlock()
+	for k, u := range pl.plan.Units {
+		if u.Status == Pending {
+			u.Status = Running
+			u.Attempts++
+			pl.plan.Units[k] = u
+			return u, true, pl.store.Save(pl.plan)
+		}
+	}
+	return Unit{}, false, nil
+}
+
+// Done marks the Unit starting at start as Done
+func (pl *Planner) Done(start time.Time) error {
+	return pl.set(start, Done, nil)
+}
+
+// Fail marks the Unit starting at start as Failed, recording err
+func (pl *Planner) Fail(start time.Time, err error) error {
+	return pl.set(start, Failed, err)
+}
+
+// Retry marks every Failed Unit as Pending again, and returns their number
+func (pl *Planner) Retry() (int, error) {
+	pl.mu.Lock()
+	defer pl.mu.Unlock()
+	count := 0
+	for k := range pl.plan.Units {
+		if pl.plan.Units[k].Status == Failed {
+			pl.plan.Units[k].Status = Pending
+			count++
+		}
+	}
+	if count == 0 {
+		return 0, nil
+	}
+	return count, pl.store.Save(pl.plan)
+}
+
+func (pl *Planner) set(start time.Time, s Status, err error) error {
+	pl.mu.Lock()
+	defer pl.mu.Unlock()
+	k, ferr := pl.plan.find(start)
+	if ferr != nil {
+		return ferr
+	}
+	pl.plan.Units[k].Status = s
+	pl.plan.Units[k].Error = ""
+	if err != nil {
+		pl.plan.Units[k].Error = err.Error()
+	}
+	return pl.store.Save(pl.plan)
+}
+
+// NeedsExtending reports whether now has moved past the end of the Plan, into a new bucket,
+// so that at least one complete bucket is not planned yet, see Extend
+func (pl *Planner) NeedsExtending(now time.Time) bool {
+	pl.mu.Lock()
+	defer pl.mu.Unlock()
+	return pl.extension(now) != nil
+}
+
+// Extend adds Pending Units for the complete buckets between the end of the Plan and now, and returns their number.
+// The bucket containing now is not complete, so it is only planned once now has moved past it.
+func (pl *Planner) Extend(now time.Time) (int, error) {
+	pl.mu.Lock()
+	defer pl.mu.Unlock()
+	ext := pl.extension(now)
+	if ext == nil {
+		return 0, nil
+	}
+	added, err := pl.plan.cover(*ext)
+	if err != nil || added == 0 {
+		return added, err
+	}
+	return added, pl.store.Save(pl.plan)
+}
+
+// extension returns the Interval from the end of the Plan to the start of the bucket containing now, if it is not empty.
+// Buckets are floored in the Location given to Open, rather than the one of the saved Plan.
+func (pl *Planner) extension(now time.Time) *timewalker.Interval {
+	end := pl.plan.Interval.End.In(pl.loc)
+	floor := pl.plan.Duration.Floor(now.In(pl.loc))
+	if !end.Before(floor) {
+		return nil
+	}
+	return &timewalker.Interval{Start: end, End: floor}
+}
@@ -0,0 +1,208 @@
+package backfill
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/daneroo/timewalker"
+	"github.com/daneroo/timewalker/tzif"
+)
+
+func parseTime(ts string) time.Time {
+	t, err := time.Parse(time.RFC3339, ts)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func parseIntvl(a, b string) timewalker.Interval {
+	return timewalker.Interval{Start: parseTime(a), End: parseTime(b)}
+}
+
+// statuses summarizes the Units of a Plan, e.g. [2001-01-01:Done 2001-01-02:Pending]
+func statuses(p Plan) string {
+	var s []string
+	for _, u := range p.Units {
+		s = append(s, u.Interval.Start.Format("2006-01-02")+":"+u.Status.String())
+	}
+	return fmt.Sprint(s)
+}
+
+func TestStatus(t *testing.T) {
+	for _, s := range []Status{Pending, Running, Done, Failed} {
+		text, err := s.MarshalText()
+		var parsed Status
+		if err != nil || parsed.UnmarshalText(text) != nil || parsed != s {
+			t.Errorf("Status %v: round trip gave %v, %v", s, parsed, err)
+		}
+	}
+	if _, err := Status(42).MarshalText(); err == nil || Status(42).String() != "Invalid" {
+		t.Errorf("Status(42): expected an invalid Status")
+	}
+}
+
+func TestPlanner(t *testing.T) {
+	store := &MemoryStore{}
+	pl, err := Open(store, parseIntvl("2001-01-01T12:00:00Z", "2001-01-04T00:00:00Z"), timewalker.Day)
+	if err != nil {
+		t.Fatalf("Open: unexpected error: %v", err)
+	}
+
+	u, ok, err := pl.Next()
+	if !ok || err != nil || u.Status != Running || u.Attempts != 1 {
+		t.Fatalf("Next: unexpected %+v, %v, %v", u, ok, err)
+	}
+	if err := pl.Done(u.Interval.Start); err != nil {
+		t.Errorf("Done: unexpected error: %v", err)
+	}
+	u, _, _ = pl.Next()
+	if err := pl.Fail(u.Interval.Start, errors.New("boom")); err != nil {
+		t.Errorf("Fail: unexpected error: %v", err)
+	}
+	u, _, _ = pl.Next() // left Running, as if the process crashed
+	exp := "[2001-01-01:Done 2001-01-02:Failed 2001-01-03:Running]"
+	if actual := statuses(pl.Plan()); actual != exp {
+		t.Errorf("before crash: \nexp: %v, \nact: %v", exp, actual)
+	}
+	if _, ok, _ := pl.Next(); ok {
+		t.Errorf("Next: expected no Pending Unit")
+	}
+	if err := pl.Done(parseTime("2001-02-01T00:00:00Z")); err == nil {
+		t.Errorf("Done: expected an error for an unknown Unit")
+	}
+
+	// resuming from the store resets Running Units, and keeps the others
+	pl, err = Open(store, parseIntvl("2001-01-01T00:00:00Z", "2001-01-04T00:00:00Z"), timewalker.Day)
+	if err != nil {
+		t.Fatalf("Open: unexpected error: %v", err)
+	}
+	plan := pl.Plan()
+	exp = "[2001-01-01:Done 2001-01-02:Failed 2001-01-03:Pending]"
+	if actual := statuses(plan); actual != exp {
+		t.Errorf("after crash: \nexp: %v, \nact: %v", exp, actual)
+	}
+	if plan.Units[1].Error != "boom" || plan.Units[2].Attempts != 1 {
+		t.Errorf("after crash: unexpected Units %+v", plan.Units)
+	}
+	if n, err := pl.Retry(); n != 1 || err != nil {
+		t.Errorf("Retry: unexpected %d, %v", n, err)
+	}
+	u, _, _ = pl.Next()
+	if u.Interval.Start != parseTime("2001-01-02T00:00:00Z") || u.Attempts != 2 {
+		t.Errorf("Next after Retry: unexpected %+v", u)
+	}
+
+	if _, err := Open(store, parseIntvl("2001-01-01T00:00:00Z", "2001-01-04T00:00:00Z"), timewalker.Month); err == nil {
+		t.Errorf("Open: expected an error for a different Duration")
+	}
+}
+
+func TestPlannerOpenCovers(t *testing.T) {
+	store := &MemoryStore{}
+	pl, _ := Open(store, parseIntvl("2001-03-01T00:00:00Z", "2001-05-01T00:00:00Z"), timewalker.Month)
+	u, _, _ := pl.Next()
+	pl.Done(u.Interval.Start)
+
+	// a wider interval adds Units on both sides
+	pl, err := Open(store, parseIntvl("2001-01-15T00:00:00Z", "2001-06-15T00:00:00Z"), timewalker.Month)
+	if err != nil {
+		t.Fatalf("Open: unexpected error: %v", err)
+	}
+	plan := pl.Plan()
+	exp := "[2001-01-01:Pending 2001-02-01:Pending 2001-03-01:Done 2001-04-01:Pending 2001-05-01:Pending 2001-06-01:Pending]"
+	if actual := statuses(plan); actual != exp {
+		t.Errorf("Open: \nexp: %v, \nact: %v", exp, actual)
+	}
+	if plan.Interval.String() != "[2001-01-01T00:00:00Z, 2001-07-01T00:00:00Z)" {
+		t.Errorf("Open: unexpected Plan Interval %v", plan.Interval)
+	}
+
+	if _, err := Open(store, timewalker.Interval{Start: parseTime("2001-01-01T00:00:00Z"), Bounds: timewalker.EndUnbounded}, timewalker.Month); err == nil {
+		t.Errorf("Open: expected an error for an unbounded Interval")
+	}
+}
+
+func TestPlannerExtend(t *testing.T) {
+	pl, _ := Open(&MemoryStore{}, parseIntvl("2001-01-01T00:00:00Z", "2001-01-03T00:00:00Z"), timewalker.Day)
+	var testData = []struct {
+		now   string // current time
+		added int    // expected number of Units added
+	}{
+		{"2001-01-02T12:00:00Z", 0},
+		{"2001-01-03T23:59:59Z", 0}, // the bucket of now is not complete
+		{"2001-01-04T00:00:00Z", 1},
+		{"2001-01-04T12:00:00Z", 0},
+		{"2001-01-07T01:00:00Z", 3},
+	}
+	for _, tt := range testData {
+		now := parseTime(tt.now)
+		if needs := pl.NeedsExtending(now); needs != (tt.added > 0) {
+			t.Errorf("NeedsExtending(%s): unexpected %v", tt.now, needs)
+		}
+		if added, err := pl.Extend(now); added != tt.added || err != nil {
+			t.Errorf("Extend(%s): exp: %d, act: %d, %v", tt.now, tt.added, added, err)
+		}
+	}
+	if plan := pl.Plan(); len(plan.Units) != 6 || plan.Interval.End != parseTime("2001-01-07T00:00:00Z") {
+		t.Errorf("Extend: unexpected Plan %v with %d Units", plan.Interval, len(plan.Units))
+	}
+}
+
+func TestPlannerExtendLocal(t *testing.T) {
+	// a Plan built in an unnamed zone, as time.Local is for TZ=EST5EDT, is saved with fixed offsets,
+	// but is extended in that zone, across the daylight savings transition
+	loc, err := tzif.POSIXLocation("", "EST5EDT")
+	if err != nil {
+		t.Fatal(err)
+	}
+	i := timewalker.Interval{
+		Start: time.Date(2007, time.March, 8, 0, 0, 0, 0, loc),
+		End:   time.Date(2007, time.March, 10, 0, 0, 0, 0, loc),
+	}
+	store := &MemoryStore{}
+	if _, err := Open(store, i, timewalker.Day); err != nil {
+		t.Fatalf("Open: unexpected error: %v", err)
+	}
+	pl, err := Open(store, i, timewalker.Day)
+	if err != nil {
+		t.Fatalf("Open: unexpected error: %v", err)
+	}
+	if added, err := pl.Extend(time.Date(2007, time.March, 13, 12, 0, 0, 0, loc)); added != 3 || err != nil {
+		t.Fatalf("Extend: exp: 3, act: %d, %v", added, err)
+	}
+	var act []string
+	for _, u := range pl.Plan().Units[2:] {
+		act = append(act, u.Interval.Start.UTC().Format(time.RFC3339))
+	}
+	exp := "[2007-03-10T05:00:00Z 2007-03-11T05:00:00Z 2007-03-12T04:00:00Z]"
+	if fmt.Sprint(act) != exp {
+		t.Errorf("Extend: \nexp: %v, \nact: %v", exp, act)
+	}
+}
+
+// A worker loop processing the monthly partitions of a quarter
+func ExamplePlanner() {
+	q1 := timewalker.Interval{
+		Start: time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC),
+	}
+	pl, _ := Open(&MemoryStore{}, q1, timewalker.Month)
+	for {
+		u, ok, _ := pl.Next()
+		if !ok {
+			break
+		}
+		fmt.Printf("processing %v\n", u.Interval)
+		pl.Done(u.Interval.Start)
+	}
+	fmt.Printf("%d done\n", pl.Plan().Count(Done))
+
+	// Output:
+	// processing [2024-01-01T00:00:00Z, 2024-02-01T00:00:00Z)
+	// processing [2024-02-01T00:00:00Z, 2024-03-01T00:00:00Z)
+	// processing [2024-03-01T00:00:00Z, 2024-04-01T00:00:00Z)
+	// 3 done
+}
@@ -0,0 +1,95 @@
+package backfill
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Store persists a Plan
+type Store interface {
+	// Load returns the saved Plan, or nil if none was saved yet
+	Load() (*Plan, error)
+	// Save replaces the saved Plan
+	Save(p *Plan) error
+}
+
+// FileStore is a Store saving the Plan as JSON in the file at Path.
+// The file is replaced atomically, by renaming a temporary file in the same directory, so a crash never leaves it half written.
+type FileStore struct {
+	Path string
+}
+
+// Load implements Store
+func (s FileStore) Load() (*Plan, error) {
+	data, err := os.ReadFile(s.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	var p Plan
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// Save implements Store
+func (s FileStore) Save(p *Plan) error {
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(s.Path), filepath.Base(s.Path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.Path)
+}
+
+// MemoryStore is a Store keeping the Plan in memory, as JSON, e.g. for tests
+type MemoryStore struct {
+	mu   sync.Mutex
+	data []byte
+}
+
+// Load implements Store
+func (s *MemoryStore) Load() (*Plan, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.data == nil {
+		return nil, nil
+	}
+	var p Plan
+	if err := json.Unmarshal(s.data, &p); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// Save implements Store
+func (s *MemoryStore) Save(p *Plan) error {
+	data, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.data = data
+	return nil
+}
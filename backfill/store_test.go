@@ -0,0 +1,53 @@
+package backfill
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/daneroo/timewalker"
+)
+
+func TestFileStore(t *testing.T) {
+	dir := t.TempDir()
+	store := FileStore{Path: filepath.Join(dir, "plan.json")}
+	if p, err := store.Load(); p != nil || err != nil {
+		t.Fatalf("Load: expected no Plan, got %v, %v", p, err)
+	}
+
+	loc, _ := time.LoadLocation("America/Montreal")
+	i := timewalker.Interval{Start: parseTime("2008-11-01T12:00:00Z").In(loc), End: parseTime("2008-11-04T12:00:00Z").In(loc)}
+	pl, err := Open(store, i, timewalker.Day)
+	if err != nil {
+		t.Fatalf("Open: unexpected error: %v", err)
+	}
+	u, _, _ := pl.Next()
+	pl.Done(u.Interval.Start)
+
+	saved, err := store.Load()
+	if err != nil {
+		t.Fatalf("Load: unexpected error: %v", err)
+	}
+	exp := "[2008-11-01:Done 2008-11-02:Pending 2008-11-03:Pending 2008-11-04:Pending]"
+	if actual := statuses(*saved); actual != exp {
+		t.Errorf("Load: \nexp: %v, \nact: %v", exp, actual)
+	}
+	// the Location survives the round trip, with the 25 hour day
+	day := saved.Units[1].Interval
+	if day.Start.Location().String() != "America/Montreal" || day.End.Sub(day.Start) != 25*time.Hour {
+		t.Errorf("Load: unexpected Unit %v", day)
+	}
+
+	// the temporary files are renamed, or removed
+	if files, _ := os.ReadDir(dir); len(files) != 1 {
+		t.Errorf("Save: expected a single file in %s, got %d", dir, len(files))
+	}
+
+	if err := os.WriteFile(store.Path, []byte("{"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Load(); err == nil {
+		t.Errorf("Load: expected an error for a corrupt file")
+	}
+}
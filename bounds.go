@@ -0,0 +1,102 @@
+package timewalker
+
+import "time"
+
+// Bounds qualifies the boundaries of an Interval; the zero value is a bounded, half-open [Start,End) interval
+type Bounds uint8
+
+// Different package constants defining the flags of Bounds
+const (
+	// StartUnbounded means the Interval extends infinitely into the past, Start is ignored
+	StartUnbounded Bounds = 1 << iota
+	// EndUnbounded means the Interval extends infinitely into the future, End is ignored
+	EndUnbounded
+	// StartExclusive means Start itself is not within the Interval: (Start,End)
+	StartExclusive
+	// EndInclusive means End itself is within the Interval: [Start,End]
+	EndInclusive
+)
+
+// Common combinations of Bounds
+const (
+	// Closed is the [Start,End] interval, e.g. "Jan 1 to Jan 31 inclusive"
+	Closed = EndInclusive
+	// Open is the (Start,End) interval
+	Open = StartExclusive
+)
+
+// IsEmpty reports whether the receiver contains no instant at all, e.g. [Start,End) when End is not after Start
+func (i Interval) IsEmpty() bool {
+	if i.Bounds&(StartUnbounded|EndUnbounded) != 0 {
+		return false
+	}
+	if i.Bounds&(StartExclusive|EndInclusive) == EndInclusive {
+		return i.End.Before(i.Start)
+	}
+	return !i.End.After(i.Start)
+}
+
+// HalfOpen normalizes the receiver to a half-open [Start,End) interval, at the granularity of the given Duration:
+// an inclusive End is replaced by the following Duration boundary, and an exclusive Start by the Duration boundary following it.
+// So [Jan 1, Jan 31] at Day becomes [Jan 1, Feb 1), and (Jan 1, Jan 31) at Day becomes [Jan 2, Jan 31). Other boundaries are left as they are.
+func (i Interval) HalfOpen(d Duration) Interval {
+	if i.Bounds&StartExclusive != 0 {
+		if i.Bounds&StartUnbounded == 0 {
+			i.Start = d.AddTo(d.Floor(i.Start))
+		}
+		i.Bounds &^= StartExclusive
+	}
+	if i.Bounds&EndInclusive != 0 {
+		if i.Bounds&EndUnbounded == 0 {
+			i.End = d.AddTo(d.Floor(i.End))
+		}
+		i.Bounds &^= EndInclusive
+	}
+	return i
+}
+
+// Inclusive is the inverse of HalfOpen, for display: it returns the Closed [Start,End] interval, where End is the start of the last Duration within the receiver,
+// so [Jan 1, Feb 1) at Day becomes [Jan 1, Jan 31], and [Jan 1, Jul 1) at Month becomes [Jan 1, Jun 1], i.e. January to June.
+// An unbounded End remains unbounded.
+func (i Interval) Inclusive(d Duration) Interval {
+	i = i.HalfOpen(d)
+	if i.Bounds&EndUnbounded == 0 {
+		i.End, _ = d.AddN(d.Ceil(i.End), -1, OverflowNormalize)
+	}
+	i.Bounds |= EndInclusive
+	return i
+}
+
+// brackets returns the opening and closing brackets which represent the receiver's Bounds: [ or ( and ) or ]
+func (i Interval) brackets() (left, right string) {
+	left, right = "[", ")"
+	if i.Bounds&StartExclusive != 0 {
+		left = "("
+	}
+	if i.Bounds&EndInclusive != 0 {
+		right = "]"
+	}
+	return left, right
+}
+
+// afterStart reports whether t is after the receiver's Start, or at Start when it is inclusive
+func (i Interval) afterStart(t time.Time) bool {
+	switch {
+	case i.Bounds&StartUnbounded != 0:
+		return true
+	case i.Bounds&StartExclusive != 0:
+		return t.After(i.Start)
+	}
+	return !t.Before(i.Start)
+}
+
+// beforeEnd reports whether t is before the receiver's End, or at End when it is inclusive
+func (i Interval) beforeEnd(t time.Time) bool {
+	switch {
+	case i.Bounds&EndUnbounded != 0:
+		return true
+	case i.Bounds&EndInclusive != 0:
+		return !t.After(i.End)
+	}
+	return t.Before(i.End)
+}
@@ -0,0 +1,170 @@
+package timewalker
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+func TestIntervalIsEmpty(t *testing.T) {
+	same := parseIntvl("2001-01-01T00:00:00Z", "2001-01-01T00:00:00Z")
+	var testData = []struct {
+		bnd Bounds // bounds
+		exp bool   // expected result
+	}{
+		{0, true},
+		{Closed, false},
+		{Open, true},
+		{StartExclusive | EndInclusive, true},
+		{StartUnbounded, false},
+		{EndUnbounded | EndInclusive, false},
+	}
+	for _, tt := range testData {
+		same.Bounds = tt.bnd
+		if actual := same.IsEmpty(); actual != tt.exp {
+			t.Errorf("%v.IsEmpty(): exp: %v act: %v", same, tt.exp, actual)
+		}
+	}
+}
+
+func TestIntervalHalfOpen(t *testing.T) {
+	var testData = []struct {
+		inp Interval // input
+		dur Duration // granularity
+		exp Interval // expected result
+	}{
+		{ // closed days
+			inp: Interval{Start: parseTime("2001-01-01T00:00:00Z"), End: parseTime("2001-01-31T00:00:00Z"), Bounds: Closed},
+			dur: Day,
+			exp: parseIntvl("2001-01-01T00:00:00Z", "2001-02-01T00:00:00Z"),
+		}, { // open days
+			inp: Interval{Start: parseTime("2001-01-01T00:00:00Z"), End: parseTime("2001-01-31T00:00:00Z"), Bounds: Open},
+			dur: Day,
+			exp: parseIntvl("2001-01-02T00:00:00Z", "2001-01-31T00:00:00Z"),
+		}, { // closed months, End within the month
+			inp: Interval{Start: parseTime("2001-01-01T00:00:00Z"), End: parseTime("2001-03-15T00:00:00Z"), Bounds: Closed},
+			dur: Month,
+			exp: parseIntvl("2001-01-01T00:00:00Z", "2001-04-01T00:00:00Z"),
+		}, { // unbounded end
+			inp: Interval{Start: parseTime("2001-01-01T00:00:00Z"), Bounds: StartExclusive | EndUnbounded | EndInclusive},
+			dur: Year,
+			exp: Interval{Start: parseTime("2002-01-01T00:00:00Z"), Bounds: EndUnbounded},
+		}, { // already half-open, not rounded
+			inp: parseIntvl("2001-01-01T12:00:00Z", "2001-01-31T12:00:00Z"),
+			dur: Day,
+			exp: parseIntvl("2001-01-01T12:00:00Z", "2001-01-31T12:00:00Z"),
+		},
+	}
+	for _, tt := range testData {
+		if actual := tt.inp.HalfOpen(tt.dur); actual != tt.exp {
+			t.Errorf("%v.HalfOpen(%s): \nexp: %v, \nact: %v", tt.inp, tt.dur, tt.exp, actual)
+		}
+	}
+}
+
+func TestIntervalInclusiveRoundTrip(t *testing.T) {
+	for _, d := range []Duration{Day, Month, Year} {
+		i, _ := parseIntvl("2001-01-01T00:00:00Z", "2004-03-01T00:00:00Z").Round(d)
+		if actual := i.Inclusive(d).HalfOpen(d); actual != i {
+			t.Errorf("%v.Inclusive(%s).HalfOpen(%s): \nexp: %v, \nact: %v", i, d, d, i, actual)
+		}
+	}
+}
+
+func TestIntervalContainsBounds(t *testing.T) {
+	i := parseIntvl("2001-01-01T00:00:00Z", "2001-01-31T00:00:00Z")
+	start, end := i.Start, i.End
+	var testData = []struct {
+		bnd        Bounds // bounds
+		start, end bool   // expected Contains(Start), Contains(End)
+	}{
+		{0, true, false},
+		{Closed, true, true},
+		{Open, false, false},
+		{StartExclusive | EndInclusive, false, true},
+	}
+	for _, tt := range testData {
+		i.Bounds = tt.bnd
+		if i.Contains(start) != tt.start || i.Contains(end) != tt.end {
+			t.Errorf("%v.Contains(Start,End): exp: %v,%v act: %v,%v", i, tt.start, tt.end, i.Contains(start), i.Contains(end))
+		}
+	}
+}
+
+func TestIntervalIntersectBounds(t *testing.T) {
+	closed := func(a, b string) Interval {
+		return Interval{Start: parseTime(a), End: parseTime(b), Bounds: Closed}
+	}
+	var testData = []struct {
+		i, j Interval // operands
+		exp  Interval // expected result
+	}{
+		{ // touching closed intervals share an instant
+			i:   closed("2001-01-01T00:00:00Z", "2001-01-02T00:00:00Z"),
+			j:   closed("2001-01-02T00:00:00Z", "2001-01-03T00:00:00Z"),
+			exp: closed("2001-01-02T00:00:00Z", "2001-01-02T00:00:00Z"),
+		}, { // same boundaries, exclusive wins
+			i:   closed("2001-01-01T00:00:00Z", "2001-01-02T00:00:00Z"),
+			j:   Interval{Start: parseTime("2001-01-01T00:00:00Z"), End: parseTime("2001-01-02T00:00:00Z"), Bounds: Open},
+			exp: Interval{Start: parseTime("2001-01-01T00:00:00Z"), End: parseTime("2001-01-02T00:00:00Z"), Bounds: Open},
+		}, { // touching, one exclusive
+			i:   closed("2001-01-01T00:00:00Z", "2001-01-02T00:00:00Z"),
+			j:   parseIntvl("2001-01-02T00:00:00Z", "2001-01-03T00:00:00Z"),
+			exp: closed("2001-01-02T00:00:00Z", "2001-01-02T00:00:00Z"),
+		}, { // touching, both exclusive
+			i:   parseIntvl("2001-01-01T00:00:00Z", "2001-01-02T00:00:00Z"),
+			j:   Interval{Start: parseTime("2001-01-02T00:00:00Z"), End: parseTime("2001-01-03T00:00:00Z"), Bounds: Closed},
+			exp: parseIntvl("2001-01-02T00:00:00Z", "2001-01-02T00:00:00Z"),
+		},
+	}
+	for _, tt := range testData {
+		for _, ops := range [][2]Interval{{tt.i, tt.j}, {tt.j, tt.i}} {
+			if actual := ops[0].Intersect(ops[1]); actual != tt.exp {
+				t.Errorf("%v.Intersect(%v): \nexp: %v, \nact: %v", ops[0], ops[1], tt.exp, actual)
+			}
+		}
+	}
+}
+
+func TestIntervalMarshalBounds(t *testing.T) {
+	i := Interval{Start: parseTime("2001-01-01T00:00:00Z"), End: parseTime("2001-01-31T00:00:00Z"), Bounds: Closed}
+	if _, err := json.Marshal(i); err == nil {
+		t.Errorf("json.Marshal(%v): expected an error", i)
+	}
+	if _, err := json.Marshal(i.HalfOpen(Day)); err != nil {
+		t.Errorf("json.Marshal(%v): unexpected error: %v", i.HalfOpen(Day), err)
+	}
+}
+
+func ExampleInterval_HalfOpen() {
+	// January, inclusive
+	i := Interval{Start: parseTime("2001-01-01T00:00:00Z"), End: parseTime("2001-01-31T00:00:00Z"), Bounds: Closed}
+	h := i.HalfOpen(Day)
+	fmt.Println(i)
+	fmt.Println(h)
+	fmt.Println(h.Inclusive(Day))
+	// Output:
+	// [2001-01-01T00:00:00Z, 2001-01-31T00:00:00Z]
+	// [2001-01-01T00:00:00Z, 2001-02-01T00:00:00Z)
+	// [2001-01-01T00:00:00Z, 2001-01-31T00:00:00Z]
+}
+
+func ExampleInterval_Inclusive() {
+	i := parseIntvl("2001-01-01T00:00:00Z", "2001-07-01T00:00:00Z")
+	c := i.Inclusive(Month)
+	fmt.Printf("%s to %s\n", c.Start.Format("Jan 2006"), c.End.Format("Jan 2006"))
+	// Output:
+	// Jan 2001 to Jun 2001
+}
+
+func TestWalkClosed(t *testing.T) {
+	i := Interval{Start: parseTime("2001-01-01T00:00:00Z"), End: parseTime("2001-01-31T00:00:00Z"), Bounds: Closed}
+	ch, _ := i.Walk(Day)
+	count := 0
+	for range ch {
+		count++
+	}
+	if count != 31 {
+		t.Errorf("%v.Walk(Day): exp: 31 days act: %d", i, count)
+	}
+}
@@ -0,0 +1,101 @@
+package timewalker
+
+import (
+	"fmt"
+	"time"
+)
+
+// CalendarDay is a cell of a calendar grid
+type CalendarDay struct {
+	Interval
+	// InMonth reports whether the day is in the displayed month, rather than padding from an adjacent month
+	InMonth bool
+}
+
+// CalendarWeek is a row of a calendar grid, 7 days from its first weekday
+type CalendarWeek struct {
+	// Interval spans the 7 days of the row
+	Interval
+	// ISOYear and ISOWeek are the ISO 8601 week of the row. When the row does not start on a Monday, it spans two ISO weeks,
+	// and the one holding most of its days, i.e. its Thursday, is used.
+	ISOYear, ISOWeek int
+	Days             [7]CalendarDay
+}
+
+// MonthCalendar is the grid of a month view: 6 rows of 7 days, padded with days from the adjacent months
+type MonthCalendar struct {
+	// Month is the displayed month
+	Month Interval
+	Weeks [6]CalendarWeek
+}
+
+// MonthGrid returns the calendar grid of the month holding t in loc, with rows starting on the first weekday, e.g. time.Monday.
+// The grid always has 6 rows, so its size does not depend on the month, and each day is an Interval produced by the Day walker,
+// so days around a DST transition are 23 or 25 hours long.
+func MonthGrid(t time.Time, first time.Weekday, loc *time.Location) (MonthCalendar, error) {
+	var c MonthCalendar
+	if loc == nil {
+		return c, fmt.Errorf("invalid Location: nil")
+	}
+	start := Month.Floor(t.In(loc))
+	c.Month = Interval{Start: start, End: Month.AddTo(start)}
+	days, err := gridDays(start, first, len(c.Weeks))
+	if err != nil {
+		return c, err
+	}
+	for w := range c.Weeks {
+		c.Weeks[w] = calendarWeek(days[w*7 : w*7+7])
+		for d := range c.Weeks[w].Days {
+			day := &c.Weeks[w].Days[d]
+			day.InMonth = !day.Start.Before(c.Month.Start) && day.Start.Before(c.Month.End)
+		}
+	}
+	return c, nil
+}
+
+// WeekGrid returns the calendar row holding t in loc, for a week view with rows starting on the first weekday.
+// Every day of a week view is InMonth.
+func WeekGrid(t time.Time, first time.Weekday, loc *time.Location) (CalendarWeek, error) {
+	if loc == nil {
+		return CalendarWeek{}, fmt.Errorf("invalid Location: nil")
+	}
+	days, err := gridDays(Day.Floor(t.In(loc)), first, 1)
+	if err != nil {
+		return CalendarWeek{}, err
+	}
+	w := calendarWeek(days)
+	for d := range w.Days {
+		w.Days[d].InMonth = true
+	}
+	return w, nil
+}
+
+// gridDays returns the days of rows weeks, starting on the first weekday on or before the day start
+func gridDays(start time.Time, first time.Weekday, rows int) ([]Interval, error) {
+	if first < time.Sunday || first > time.Saturday {
+		return nil, fmt.Errorf("invalid first weekday: %d", first)
+	}
+	start, _ = Day.AddN(start, -mod(int(start.Weekday()-first), 7), OverflowNormalize)
+	end, _ := Day.AddN(start, 7*rows, OverflowNormalize)
+	walk, err := Interval{Start: start, End: end}.Walk(Day)
+	if err != nil {
+		return nil, err
+	}
+	var days []Interval
+	for day := range walk {
+		days = append(days, day)
+	}
+	return days, nil
+}
+
+// calendarWeek returns the row of the 7 given days
+func calendarWeek(days []Interval) CalendarWeek {
+	w := CalendarWeek{Interval: Interval{Start: days[0].Start, End: days[6].End}}
+	for d, day := range days {
+		w.Days[d].Interval = day
+		if day.Start.Weekday() == time.Thursday {
+			w.ISOYear, w.ISOWeek = day.Start.ISOWeek()
+		}
+	}
+	return w
+}
@@ -0,0 +1,124 @@
+package timewalker
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+// formatRows summarizes a MonthCalendar, one row per week, e.g. 2024-W05: 29* 30* 31* 1 2 3 4, where * marks padding days
+func formatRows(c MonthCalendar) []string {
+	var rows []string
+	for _, w := range c.Weeks {
+		row := fmt.Sprintf("%d-W%02d:", w.ISOYear, w.ISOWeek)
+		for _, day := range w.Days {
+			row += fmt.Sprintf(" %d", day.Start.Day())
+			if !day.InMonth {
+				row += "*"
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+func TestMonthGrid(t *testing.T) {
+	var testData = []struct {
+		t     string       // a time in the month
+		first time.Weekday // first weekday of rows
+		exp   string       // expected rows, separated by |
+	}{
+		{"2024-02-15T12:00:00Z", time.Monday, "2024-W05: 29* 30* 31* 1 2 3 4|2024-W06: 5 6 7 8 9 10 11|2024-W07: 12 13 14 15 16 17 18|2024-W08: 19 20 21 22 23 24 25|2024-W09: 26 27 28 29 1* 2* 3*|2024-W10: 4* 5* 6* 7* 8* 9* 10*"},
+		{"2024-02-01T00:00:00Z", time.Sunday, "2024-W05: 28* 29* 30* 31* 1 2 3|2024-W06: 4 5 6 7 8 9 10|2024-W07: 11 12 13 14 15 16 17|2024-W08: 18 19 20 21 22 23 24|2024-W09: 25 26 27 28 29 1* 2*|2024-W10: 3* 4* 5* 6* 7* 8* 9*"},
+		{"2024-04-30T23:59:59Z", time.Monday, "2024-W14: 1 2 3 4 5 6 7|2024-W15: 8 9 10 11 12 13 14|2024-W16: 15 16 17 18 19 20 21|2024-W17: 22 23 24 25 26 27 28|2024-W18: 29 30 1* 2* 3* 4* 5*|2024-W19: 6* 7* 8* 9* 10* 11* 12*"},
+		{"2025-01-10T00:00:00Z", time.Saturday, "2025-W01: 28* 29* 30* 31* 1 2 3|2025-W02: 4 5 6 7 8 9 10|2025-W03: 11 12 13 14 15 16 17|2025-W04: 18 19 20 21 22 23 24|2025-W05: 25 26 27 28 29 30 31|2025-W06: 1* 2* 3* 4* 5* 6* 7*"},
+	}
+	for _, tt := range testData {
+		c, err := MonthGrid(parseTime(tt.t), tt.first, time.UTC)
+		if act := strings.Join(formatRows(c), "|"); err != nil || act != tt.exp {
+			t.Errorf("MonthGrid(%v, %v): \nexp: %v, \nact: %v, %v", tt.t, tt.first, tt.exp, act, err)
+		}
+	}
+}
+
+func TestMonthGridLocation(t *testing.T) {
+	loc, _ := time.LoadLocation("America/Montreal")
+	// 2024-03-01 in UTC is still February in Montreal
+	c, err := MonthGrid(parseTime("2024-03-01T02:00:00Z"), time.Sunday, loc)
+	if err != nil || c.Month.String() != "[2024-02-01T00:00:00-05:00, 2024-03-01T00:00:00-05:00)" {
+		t.Errorf("MonthGrid in %v: unexpected month %v, %v", loc, c.Month, err)
+	}
+	c, _ = MonthGrid(parseTime("2024-03-15T12:00:00Z"), time.Sunday, loc)
+	var previous CalendarDay
+	for w, week := range c.Weeks {
+		if week.Start != week.Days[0].Start || week.End != week.Days[6].End {
+			t.Errorf("MonthGrid in %v: row %d spans %v", loc, w, week.Interval)
+		}
+		for _, day := range week.Days {
+			if day.Start.Location() != loc || day.Start.Hour() != 0 || (!previous.End.IsZero() && day.Start != previous.End) {
+				t.Errorf("MonthGrid in %v: unexpected day %v after %v", loc, day.Interval, previous.Interval)
+			}
+			if hours := day.End.Sub(day.Start).Hours(); hours != 24 && day.Start.Day() != 10 {
+				t.Errorf("MonthGrid in %v: %v has %v hours", loc, day.Interval, hours)
+			} else if day.Start.Day() == 10 && day.InMonth && hours != 23 {
+				t.Errorf("MonthGrid in %v: %v has %v hours, expected 23", loc, day.Interval, hours)
+			}
+			previous = day
+		}
+	}
+}
+
+func TestMonthGridErrors(t *testing.T) {
+	if _, err := MonthGrid(parseTime("2024-02-15T12:00:00Z"), 7, time.UTC); err == nil || err.Error() != "invalid first weekday: 7" {
+		t.Errorf("MonthGrid with an invalid weekday: %v", err)
+	}
+	if _, err := MonthGrid(parseTime("2024-02-15T12:00:00Z"), time.Monday, nil); err == nil || err.Error() != "invalid Location: nil" {
+		t.Errorf("MonthGrid with a nil Location: %v", err)
+	}
+}
+
+func TestWeekGrid(t *testing.T) {
+	var testData = []struct {
+		t     string       // a time in the week
+		first time.Weekday // first weekday of the row
+		exp   string       // expected row
+	}{
+		{"2024-12-31T12:00:00Z", time.Monday, "2025-W01: [2024-12-30T00:00:00Z, 2025-01-06T00:00:00Z)"},
+		{"2024-12-31T12:00:00Z", time.Sunday, "2025-W01: [2024-12-29T00:00:00Z, 2025-01-05T00:00:00Z)"},
+		{"2024-12-28T12:00:00Z", time.Sunday, "2024-W52: [2024-12-22T00:00:00Z, 2024-12-29T00:00:00Z)"},
+		{"2024-12-28T12:00:00Z", time.Saturday, "2025-W01: [2024-12-28T00:00:00Z, 2025-01-04T00:00:00Z)"},
+	}
+	for _, tt := range testData {
+		w, err := WeekGrid(parseTime(tt.t), tt.first, time.UTC)
+		if act := fmt.Sprintf("%d-W%02d: %v", w.ISOYear, w.ISOWeek, w.Interval); err != nil || act != tt.exp || !w.Days[3].InMonth {
+			t.Errorf("WeekGrid(%v, %v): \nexp: %v, \nact: %v, %v", tt.t, tt.first, tt.exp, act, err)
+		}
+	}
+}
+
+func ExampleMonthGrid() {
+	c, _ := MonthGrid(time.Date(2024, time.February, 14, 0, 0, 0, 0, time.UTC), time.Monday, time.UTC)
+	fmt.Println(c.Month.Start.Format("January 2006"))
+	fmt.Println("    Mo Tu We Th Fr Sa Su")
+	for _, week := range c.Weeks {
+		fmt.Printf("W%02d ", week.ISOWeek)
+		for _, day := range week.Days {
+			if day.InMonth {
+				fmt.Printf(" %2d", day.Start.Day())
+			} else {
+				fmt.Print("  .")
+			}
+		}
+		fmt.Println()
+	}
+	// Output:
+	// February 2024
+	//     Mo Tu We Th Fr Sa Su
+	// W05   .  .  .  1  2  3  4
+	// W06   5  6  7  8  9 10 11
+	// W07  12 13 14 15 16 17 18
+	// W08  19 20 21 22 23 24 25
+	// W09  26 27 28 29  .  .  .
+	// W10   .  .  .  .  .  .  .
+}
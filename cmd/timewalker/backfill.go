@@ -0,0 +1,208 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/daneroo/timewalker"
+)
+
+// execUnit runs the command of a unit, replaced in tests
+var execUnit = defaultExecUnit
+
+func defaultExecUnit(argv []string, stdout, stderr io.Writer) error {
+	cmd := exec.Command(argv[0], argv[1:]...)
+	cmd.Stdout, cmd.Stderr = stdout, stderr
+	return cmd.Run()
+}
+
+// runBackfill runs a command template once per interval of the walk from --from to --to, after substituting {start}, {end} and {label} in its arguments.
+// Completed units are appended to the --checkpoint file, by label, and skipped when the backfill is run again.
+// By default, no unit is started after one fails; with --keep-going, all units are run and the failures are reported at the end.
+func runBackfill(args []string, stdout, stderr io.Writer) error {
+	var o options
+	var from, to, label, checkpoint string
+	var concurrency int
+	var keepGoing, dryRun bool
+	fs := newFlagSet("backfill", &o, stderr)
+	fs.StringVar(&from, "from", "", "start `time` of the backfill")
+	fs.StringVar(&to, "to", "", "end `time` of the backfill, excluded")
+	fs.StringVar(&label, "label", "", "Go `layout` of {label}, by default a label according to --by, e.g. 2024-03-07, 2024-W10, 2024-03, 2024-Q1 or 2024")
+	fs.StringVar(&checkpoint, "checkpoint", "", "`file` recording the labels of completed units, to resume from")
+	fs.IntVar(&concurrency, "concurrency", 1, "maximum `number` of units run at once")
+	fs.BoolVar(&keepGoing, "keep-going", false, "keep running units after a failure")
+	fs.BoolVar(&dryRun, "dry-run", false, "print the planned invocations, without running them")
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: timewalker backfill [flags] -- command [args...]\n\nArguments may contain {start}, {end} and {label}.\n\n")
+		fs.PrintDefaults()
+	}
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		return fmt.Errorf("backfill: missing command")
+	}
+	if concurrency < 1 {
+		return fmt.Errorf("backfill: --concurrency must be at least 1, got %d", concurrency)
+	}
+	if o.format != "text" {
+		return fmt.Errorf("backfill: --format is not supported")
+	}
+	d, loc, _, err := o.resolve(stdout)
+	if err != nil {
+		return err
+	}
+	if o.layout == "" {
+		o.layout = time.RFC3339
+	}
+	i, err := parseInterval(from, to, loc)
+	if err != nil {
+		return err
+	}
+	done, err := readCheckpoint(checkpoint)
+	if err != nil {
+		return err
+	}
+
+	ch, err := i.Walk(d)
+	if err != nil {
+		return err
+	}
+	var units []unit
+	for i := range ch {
+		u := unit{label: i.Label(d), interval: i}
+		if label != "" {
+			u.label = i.Start.Format(label)
+		}
+		if !done[u.label] {
+			u.argv = u.expand(fs.Args(), o.layout)
+			units = append(units, u)
+		}
+	}
+
+	if dryRun {
+		for _, u := range units {
+			fmt.Fprintln(stdout, shellJoin(u.argv))
+		}
+		return nil
+	}
+	return backfill(units, concurrency, keepGoing, checkpoint, stdout, stderr)
+}
+
+// unit is an interval of the backfill, and the command run for it
+type unit struct {
+	label    string
+	interval timewalker.Interval
+	argv     []string
+}
+
+// expand substitutes {start}, {end} and {label} in the command template
+func (u unit) expand(template []string, layout string) []string {
+	r := strings.NewReplacer(
+		"{start}", u.interval.Start.Format(layout),
+		"{end}", u.interval.End.Format(layout),
+		"{label}", u.label,
+	)
+	argv := make([]string, len(template))
+	for i, arg := range template {
+		argv[i] = r.Replace(arg)
+	}
+	return argv
+}
+
+// backfill runs the units, at most concurrency at once, in order of their start
+func backfill(units []unit, concurrency int, keepGoing bool, checkpoint string, stdout, stderr io.Writer) error {
+	var (
+		wg     sync.WaitGroup
+		mu     sync.Mutex // guards failed, and writes to the checkpoint and stderr
+		failed []string
+	)
+	sem := make(chan struct{}, concurrency)
+	for _, u := range units {
+		sem <- struct{}{}
+		mu.Lock()
+		stop := len(failed) > 0 && !keepGoing
+		mu.Unlock()
+		if stop {
+			<-sem
+			break
+		}
+		wg.Add(1)
+		go func(u unit) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			err := execUnit(u.argv, stdout, stderr)
+			mu.Lock()
+			defer mu.Unlock()
+			if err == nil {
+				err = appendCheckpoint(checkpoint, u.label)
+			}
+			if err != nil {
+				fmt.Fprintf(stderr, "timewalker: backfill %s: %v\n", u.label, err)
+				failed = append(failed, u.label)
+			}
+		}(u)
+	}
+	wg.Wait()
+	if len(failed) > 0 {
+		return fmt.Errorf("backfill: %d unit(s) failed: %s", len(failed), strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+// readCheckpoint returns the labels recorded in the checkpoint file, which may not exist yet
+func readCheckpoint(name string) (map[string]bool, error) {
+	done := make(map[string]bool)
+	if name == "" {
+		return done, nil
+	}
+	f, err := os.Open(name)
+	if errors.Is(err, os.ErrNotExist) {
+		return done, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			done[line] = true
+		}
+	}
+	return done, scanner.Err()
+}
+
+// appendCheckpoint records the label of a completed unit in the checkpoint file, if any
+func appendCheckpoint(name, label string) error {
+	if name == "" {
+		return nil
+	}
+	f, err := os.OpenFile(name, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintln(f, label); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// shellJoin formats argv as a POSIX shell command line, quoting the arguments which need it
+func shellJoin(argv []string) string {
+	quoted := make([]string, len(argv))
+	for i, arg := range argv {
+		quoted[i] = arg
+		if arg == "" || strings.ContainsAny(arg, " \t\n\"'\\$`!*?[]{}()<>|&;#~") {
+			quoted[i] = "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+		}
+	}
+	return strings.Join(quoted, " ")
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeExec replaces execUnit with a recorder of invocations, failing those whose last argument is in fail
+func fakeExec(fail ...string) (invocations func() []string, restore func()) {
+	var mu sync.Mutex
+	var calls []string
+	execUnit = func(argv []string, stdout, stderr io.Writer) error {
+		mu.Lock()
+		defer mu.Unlock()
+		calls = append(calls, strings.Join(argv, " "))
+		for _, f := range fail {
+			if argv[len(argv)-1] == f {
+				return fmt.Errorf("exit status 1")
+			}
+		}
+		return nil
+	}
+	invocations = func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), calls...)
+	}
+	return invocations, func() { execUnit = defaultExecUnit }
+}
+
+func TestBackfillDryRun(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	args := "backfill --from 2020-01-30 --to 2020-02-02 --tz America/Montreal --dry-run -- job --at {start} --until {end} {label}"
+	if err := run(strings.Fields(args), &stdout, &stderr); err != nil {
+		t.Fatalf("timewalker %s: unexpected error: %v", args, err)
+	}
+	exp := "job --at 2020-01-30T00:00:00-05:00 --until 2020-01-31T00:00:00-05:00 2020-01-30\n" +
+		"job --at 2020-01-31T00:00:00-05:00 --until 2020-02-01T00:00:00-05:00 2020-01-31\n" +
+		"job --at 2020-02-01T00:00:00-05:00 --until 2020-02-02T00:00:00-05:00 2020-02-01\n"
+	if actual := stdout.String(); actual != exp {
+		t.Errorf("timewalker %s: \nexp: %q, \nact: %q", args, exp, actual)
+	}
+}
+
+func TestBackfill(t *testing.T) {
+	var testData = []struct {
+		args string   // command line
+		fail []string // labels whose command fails
+		exp  []string // expected invocations, sorted
+		err  string   // expected error, or ""
+	}{
+		{
+			args: "backfill --from 2020-01-01 --to 2020-04-01 --by month --concurrency 2 -- job {label}",
+			exp:  []string{"job 2020-01", "job 2020-02", "job 2020-03"},
+		}, {
+			args: "backfill --from 2020-01-01 --to 2020-07-01 --by quarter -- job {label}",
+			exp:  []string{"job 2020-Q1", "job 2020-Q2"},
+		}, {
+			args: "backfill --from 2020-01-01 --to 2020-01-04 --label 20060102 --layout 2006-01-02 -- job {start} {end} {label}",
+			exp:  []string{"job 2020-01-01 2020-01-02 20200101", "job 2020-01-02 2020-01-03 20200102", "job 2020-01-03 2020-01-04 20200103"},
+		}, { // stops at the first failure
+			args: "backfill --from 2020-01-01 --to 2020-01-05 -- job {label}",
+			fail: []string{"2020-01-02"},
+			exp:  []string{"job 2020-01-01", "job 2020-01-02"},
+			err:  "backfill: 1 unit(s) failed: 2020-01-02",
+		}, {
+			args: "backfill --from 2020-01-01 --to 2020-01-05 --keep-going -- job {label}",
+			fail: []string{"2020-01-02", "2020-01-03"},
+			exp:  []string{"job 2020-01-01", "job 2020-01-02", "job 2020-01-03", "job 2020-01-04"},
+			err:  "backfill: 2 unit(s) failed: 2020-01-02, 2020-01-03",
+		},
+	}
+	for _, tt := range testData {
+		invocations, restore := fakeExec(tt.fail...)
+		var stdout, stderr bytes.Buffer
+		err := run(strings.Fields(tt.args), &stdout, &stderr)
+		restore()
+		if (err == nil && tt.err != "") || (err != nil && err.Error() != tt.err) {
+			t.Errorf("timewalker %s: \nexp error: %q, \nact: %v", tt.args, tt.err, err)
+		}
+		actual := invocations()
+		sort.Strings(actual)
+		if fmt.Sprint(actual) != fmt.Sprint(tt.exp) {
+			t.Errorf("timewalker %s: \nexp: %q, \nact: %q", tt.args, tt.exp, actual)
+		}
+	}
+}
+
+func TestBackfillCheckpoint(t *testing.T) {
+	checkpoint := filepath.Join(t.TempDir(), "done.txt")
+	args := strings.Fields("backfill --from 2020-01-01 --to 2020-01-05 --checkpoint " + checkpoint + " -- job {label}")
+	var stdout, stderr bytes.Buffer
+
+	// the first run fails on the third day
+	invocations, restore := fakeExec("2020-01-03")
+	if err := run(args, &stdout, &stderr); err == nil {
+		t.Errorf("timewalker %s: expected an error", args)
+	}
+	restore()
+	if data, _ := ioutil.ReadFile(checkpoint); string(data) != "2020-01-01\n2020-01-02\n" {
+		t.Errorf("checkpoint after failure: %q", data)
+	}
+
+	// the second run resumes from the failed day
+	invocations, restore = fakeExec()
+	defer restore()
+	if err := run(args, &stdout, &stderr); err != nil {
+		t.Errorf("timewalker %s: unexpected error: %v", args, err)
+	}
+	if actual := invocations(); fmt.Sprint(actual) != "[job 2020-01-03 job 2020-01-04]" {
+		t.Errorf("resumed invocations: %q", actual)
+	}
+	if data, _ := ioutil.ReadFile(checkpoint); string(data) != "2020-01-01\n2020-01-02\n2020-01-03\n2020-01-04\n" {
+		t.Errorf("checkpoint after resume: %q", data)
+	}
+}
+
+func TestBackfillErrors(t *testing.T) {
+	var testData = []struct {
+		args string // command line
+		exp  string // expected error
+	}{
+		{"backfill --from 2020-01-01 --to 2020-01-05", "backfill: missing command"},
+		{"backfill --from 2020-01-01 --to 2020-01-05 --concurrency 0 -- job", "backfill: --concurrency must be at least 1, got 0"},
+		{"backfill --from 2020-01-01 --to 2020-01-05 --format csv -- job", "backfill: --format is not supported"},
+	}
+	for _, tt := range testData {
+		var stdout, stderr bytes.Buffer
+		err := run(strings.Fields(tt.args), &stdout, &stderr)
+		if err == nil || err.Error() != tt.exp {
+			t.Errorf("timewalker %s: \nexp: %v, \nact: %v", tt.args, tt.exp, err)
+		}
+	}
+}
+
+func TestShellJoin(t *testing.T) {
+	argv := []string{"sh", "-c", "echo it's {label}", ""}
+	exp := `sh -c 'echo it'\''s {label}' ''`
+	if actual := shellJoin(argv); actual != exp {
+		t.Errorf("shellJoin(%q): \nexp: %s, \nact: %s", argv, exp, actual)
+	}
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"io"
+
+	"github.com/daneroo/timewalker"
+)
+
+// runDST prints the UTC offset transitions of --tz from --from (incl) to --to (excl)
+func runDST(args []string, stdout, stderr io.Writer) error {
+	var o options
+	var from, to string
+	fs := newFlagSet("dst", &o, stderr)
+	fs.StringVar(&from, "from", "", "start `time` of the search")
+	fs.StringVar(&to, "to", "", "end `time` of the search, excluded")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	_, loc, p, err := o.resolve(stdout)
+	if err != nil {
+		return err
+	}
+	i, err := parseInterval(from, to, loc)
+	if err != nil {
+		return err
+	}
+	transitions, err := timewalker.Transitions(loc, i)
+	if err != nil {
+		return err
+	}
+	for _, tr := range transitions {
+		if err := p.transition(tr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
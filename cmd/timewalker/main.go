@@ -0,0 +1,162 @@
+// Command timewalker prints sequences of days, weeks, months, quarters or years, and their boundaries, in a given time zone.
+//
+// Usage:
+//
+//	timewalker walk --from 2020-01-01 --to 2021-01-01 --by month --tz America/Montreal
+//	timewalker floor --by month --tz America/Montreal 2020-03-14T15:09:26Z
+//	timewalker ceil --by day 2020-03-14T15:09:26Z
+//	timewalker round --from 2020-01-15 --to 2020-03-14 --by month
+//	timewalker dst --from 2020-01-01 --to 2021-01-01 --tz America/Montreal
+//	timewalker backfill --from 2020-01-01 --to 2020-02-01 --concurrency 4 --checkpoint done.txt -- ./job.sh {start} {end} {label}
+//	timewalker serve --addr localhost:8080
+//
+// Times are given as dates (2020-01-01), wall clock times (2020-01-01T12:00:00) or RFC 3339 instants (2020-01-01T12:00:00Z),
+// the first two being read in the --tz zone. Output is selected with --format: text, rfc3339, jsonl or csv,
+// and times are formatted with a Go layout given by --layout.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/daneroo/timewalker"
+)
+
+// command is a timewalker subcommand
+type command struct {
+	usage string
+	run   func(args []string, stdout, stderr io.Writer) error
+}
+
+var commands = map[string]command{
+	"walk":     {"walk the intervals, or instants, between two times", runWalk},
+	"floor":    {"round times down to a boundary", runFloor},
+	"ceil":     {"round times up to a boundary", runCeil},
+	"round":    {"round an interval outward to boundaries", runRound},
+	"dst":      {"list the UTC offset transitions of a time zone", runDST},
+	"backfill": {"run a command once per interval of a walk", runBackfill},
+	"serve":    {"serve walking and rounding over HTTP/JSON", runServe},
+}
+
+// errUsage is returned when the command line is invalid, after the problem has been reported
+var errUsage = errors.New("usage")
+
+// now is the clock used to resolve the time "now", replaced in tests
+var now = time.Now
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout, os.Stderr); err != nil {
+		if err == errUsage {
+			os.Exit(2)
+		}
+		fmt.Fprintf(os.Stderr, "timewalker: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// run executes the subcommand named by the first argument
+func run(args []string, stdout, stderr io.Writer) error {
+	if len(args) == 0 {
+		usage(stderr)
+		return errUsage
+	}
+	if args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		usage(stdout)
+		return nil
+	}
+	cmd, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprintf(stderr, "timewalker: unknown command %q\n", args[0])
+		usage(stderr)
+		return errUsage
+	}
+	if err := cmd.run(args[1:], stdout, stderr); err != flag.ErrHelp {
+		return err
+	}
+	return nil
+}
+
+func usage(w io.Writer) {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	fmt.Fprintf(w, "Usage: timewalker <command> [flags]\n\nCommands:\n")
+	for _, name := range names {
+		fmt.Fprintf(w, "  %-8s %s\n", name, commands[name].usage)
+	}
+	fmt.Fprintf(w, "\nRun 'timewalker <command> -h' for the flags of a command.\n")
+}
+
+// options are the flags shared by the subcommands
+type options struct {
+	by     string
+	tz     string
+	format string
+	layout string
+}
+
+// newFlagSet returns a FlagSet for the named subcommand, with the shared flags registered in o
+func newFlagSet(name string, o *options, stderr io.Writer) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.StringVar(&o.by, "by", "day", "boundary `unit`: day, week, month, quarter or year")
+	fs.StringVar(&o.tz, "tz", "UTC", "time `zone`, e.g. America/Montreal")
+	fs.StringVar(&o.format, "format", "text", "output `format`: text, rfc3339, jsonl or csv")
+	fs.StringVar(&o.layout, "layout", "", "Go `layout` for times, e.g. 2006-01-02")
+	return fs
+}
+
+// parse parses the command line into fs, reporting errors as errUsage, except for flag.ErrHelp
+func parse(fs *flag.FlagSet, args []string) error {
+	err := fs.Parse(args)
+	if err != nil && err != flag.ErrHelp {
+		return errUsage
+	}
+	return err
+}
+
+// resolve returns the Duration named by --by, the Location named by --tz, and the printer selected by --format and --layout.
+// Zones are loaded with timewalker.LoadZone, from the embedded zones if possible, or else from the host's zone database.
+func (o *options) resolve(w io.Writer) (timewalker.Duration, *time.Location, *printer, error) {
+	d, err := timewalker.ParseDuration(o.by)
+	if err != nil {
+		return d, nil, nil, err
+	}
+	loc, err := timewalker.LoadZone(o.tz)
+	if err != nil {
+		return d, nil, nil, err
+	}
+	p, err := newPrinter(w, o.format, o.layout)
+	return d, loc, p, err
+}
+
+// parseTime parses a time argument: "now", or a time in loc, see timewalker.ParseTimeIn
+func parseTime(s string, loc *time.Location) (time.Time, error) {
+	if s == "now" {
+		return now().In(loc), nil
+	}
+	return timewalker.ParseTimeIn(s, loc)
+}
+
+// parseInterval parses the --from and --to times into an Interval in loc
+func parseInterval(from, to string, loc *time.Location) (timewalker.Interval, error) {
+	if from == "" || to == "" {
+		return timewalker.Interval{}, fmt.Errorf("both --from and --to are required")
+	}
+	start, err := parseTime(from, loc)
+	if err != nil {
+		return timewalker.Interval{}, err
+	}
+	end, err := parseTime(to, loc)
+	if err != nil {
+		return timewalker.Interval{}, err
+	}
+	return timewalker.Interval{Start: start, End: end}, nil
+}
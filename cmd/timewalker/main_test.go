@@ -0,0 +1,103 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRun(t *testing.T) {
+	now = func() time.Time { return time.Date(2020, time.March, 14, 15, 9, 26, 0, time.UTC) }
+	defer func() { now = time.Now }()
+
+	var testData = []struct {
+		args string // command line
+		exp  string // expected output
+	}{
+		{
+			"walk --from 2020-01-01 --to 2020-04-01 --by month --tz America/Montreal",
+			"[2020-01-01 00:00:00 EST, 2020-02-01 00:00:00 EST)\n[2020-02-01 00:00:00 EST, 2020-03-01 00:00:00 EST)\n[2020-03-01 00:00:00 EST, 2020-04-01 00:00:00 EDT)\n",
+		}, {
+			"walk --from 2020-03-07 --to 2020-03-10 --tz America/Montreal --format rfc3339 --instants",
+			"2020-03-07T00:00:00-05:00\n2020-03-08T00:00:00-05:00\n2020-03-09T00:00:00-04:00\n",
+		}, {
+			"walk --from 2020-01-01 --to 2022-01-01 --by year --format csv --layout 2006",
+			"start,end\n2020,2021\n2021,2022\n",
+		}, {
+			"walk --from 2020-01-31T12:00:00Z --to 2020-02-02T00:00:00Z --format jsonl",
+			`{"start":"2020-01-31T00:00:00Z","end":"2020-02-01T00:00:00Z"}` + "\n" + `{"start":"2020-02-01T00:00:00Z","end":"2020-02-02T00:00:00Z"}` + "\n",
+		}, {
+			"walk --from 2020-01-01 --to 2020-01-03 --format rfc3339",
+			"2020-01-01T00:00:00Z/2020-01-02T00:00:00Z\n2020-01-02T00:00:00Z/2020-01-03T00:00:00Z\n",
+		}, {
+			"walk --from 2024-12-25 --to 2025-01-10 --by week --format csv --layout 2006-01-02",
+			"start,end\n2024-12-23,2024-12-30\n2024-12-30,2025-01-06\n2025-01-06,2025-01-13\n",
+		}, {
+			"floor --by month --tz America/Montreal --format rfc3339 2020-03-14T15:09:26Z 2020-03-08T02:30:00 now",
+			"2020-03-01T00:00:00-05:00\n2020-03-01T00:00:00-05:00\n2020-03-01T00:00:00-05:00\n",
+		}, {
+			"ceil --by year --format jsonl 2020-03-14T15:09:26Z",
+			`{"time":"2021-01-01T00:00:00Z"}` + "\n",
+		}, {
+			"round --from 2020-01-15 --to 2020-03-14 --by month --tz Asia/Tokyo --format rfc3339",
+			"2020-01-01T00:00:00+09:00/2020-04-01T00:00:00+09:00\n",
+		}, {
+			"dst --from 2020-01-01 --to 2021-01-01 --tz America/Montreal",
+			"2020-03-08 03:00:00 EDT EST(-18000)->EDT(-14400) gap\n2020-11-01 01:00:00 EST EDT(-14400)->EST(-18000) overlap\n",
+		}, {
+			"dst --from 2020-01-01 --to 2021-01-01 --tz Europe/London --format csv",
+			"at,old_offset,new_offset,old_name,new_name,kind\n2020-03-29T02:00:00+01:00,0,3600,GMT,BST,gap\n2020-10-25T01:00:00Z,3600,0,BST,GMT,overlap\n",
+		},
+	}
+	for _, tt := range testData {
+		var stdout, stderr bytes.Buffer
+		if err := run(strings.Fields(tt.args), &stdout, &stderr); err != nil {
+			t.Errorf("timewalker %s: unexpected error: %v", tt.args, err)
+			continue
+		}
+		if actual := stdout.String(); actual != tt.exp {
+			t.Errorf("timewalker %s: \nexp: %q, \nact: %q", tt.args, tt.exp, actual)
+		}
+	}
+}
+
+func TestRunErrors(t *testing.T) {
+	var testData = []struct {
+		args string // command line
+		exp  string // expected error
+	}{
+		{"", "usage"},
+		{"stroll", "usage"},
+		{"walk --bogus", "usage"},
+		{"walk --from 2020-01-01", "both --from and --to are required"},
+		{"walk --from 2020-01-01 --to tomorrow", `invalid time "tomorrow": expected a date, a wall clock time or an RFC 3339 instant`},
+		{"walk --from 2020-01-01 --to 2020-02-01 --by fortnight", `invalid Duration: "fortnight"`},
+		{"walk --from 2020-01-01 --to 2020-02-01 --tz Mars/Olympus_Mons", "unknown time zone Mars/Olympus_Mons"},
+		{"walk --from 2020-01-01 --to 2020-02-01 --format xml", `invalid format "xml": expected text, rfc3339, jsonl or csv`},
+		{"walk --from 2020-01-01 --to 2020-02-01 --format rfc3339 --layout 2006", "--layout cannot be used with --format rfc3339"},
+		{"floor --by month", "floor: missing time argument"},
+		{"serve --addr localhost:-1", "listen tcp: address -1: invalid port"},
+	}
+	for _, tt := range testData {
+		var stdout, stderr bytes.Buffer
+		err := run(strings.Fields(tt.args), &stdout, &stderr)
+		if err == nil || err.Error() != tt.exp {
+			t.Errorf("timewalker %s: \nexp: %v, \nact: %v", tt.args, tt.exp, err)
+		}
+	}
+}
+
+func TestRunHelp(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if err := run([]string{"walk", "-h"}, &stdout, &stderr); err != nil {
+		t.Errorf("timewalker walk -h: unexpected error: %v", err)
+	}
+	if !strings.Contains(stderr.String(), "-instants") {
+		t.Errorf("timewalker walk -h: expected the flags in %q", stderr.String())
+	}
+	stdout.Reset()
+	if err := run([]string{"help"}, &stdout, &stderr); err != nil || !strings.Contains(stdout.String(), "walk") {
+		t.Errorf("timewalker help: unexpected output %q, %v", stdout.String(), err)
+	}
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/daneroo/timewalker"
+)
+
+// textLayout is the default layout of the text format
+const textLayout = "2006-01-02 15:04:05 MST"
+
+// printer writes times, intervals and transitions in one of the output formats:
+//   - text: human readable, e.g. [2020-01-01 00:00:00 EST, 2020-02-01 00:00:00 EST)
+//   - rfc3339: RFC 3339 instants, and ISO 8601 start/end intervals
+//   - jsonl: one JSON object per line
+//   - csv: comma separated values, with a header line
+type printer struct {
+	w      io.Writer
+	format string
+	layout string
+	csv    *csv.Writer
+	header bool // whether the csv header has been written
+}
+
+func newPrinter(w io.Writer, format, layout string) (*printer, error) {
+	p := &printer{w: w, format: format, layout: layout}
+	switch format {
+	case "text":
+		if p.layout == "" {
+			p.layout = textLayout
+		}
+	case "rfc3339":
+		if layout != "" {
+			return nil, fmt.Errorf("--layout cannot be used with --format rfc3339")
+		}
+		p.layout = time.RFC3339Nano
+	case "jsonl", "csv":
+		if p.layout == "" {
+			p.layout = time.RFC3339Nano
+		}
+		p.csv = csv.NewWriter(w)
+	default:
+		return nil, fmt.Errorf("invalid format %q: expected text, rfc3339, jsonl or csv", format)
+	}
+	return p, nil
+}
+
+func (p *printer) time(t time.Time) error {
+	s := t.Format(p.layout)
+	switch p.format {
+	case "jsonl":
+		return p.json(struct {
+			Time string `json:"time"`
+		}{s})
+	case "csv":
+		return p.row([]string{"time"}, []string{s})
+	}
+	_, err := fmt.Fprintln(p.w, s)
+	return err
+}
+
+func (p *printer) interval(i timewalker.Interval) error {
+	start, end := i.Start.Format(p.layout), i.End.Format(p.layout)
+	switch p.format {
+	case "text":
+		_, err := fmt.Fprintf(p.w, "[%s, %s)\n", start, end)
+		return err
+	case "jsonl":
+		return p.json(struct {
+			Start string `json:"start"`
+			End   string `json:"end"`
+		}{start, end})
+	case "csv":
+		return p.row([]string{"start", "end"}, []string{start, end})
+	}
+	_, err := fmt.Fprintf(p.w, "%s/%s\n", start, end)
+	return err
+}
+
+func (p *printer) transition(tr timewalker.Transition) error {
+	at, kind := tr.At.Format(p.layout), "offset"
+	if tr.IsGap() {
+		kind = "gap"
+	} else if tr.IsOverlap() {
+		kind = "overlap"
+	}
+	switch p.format {
+	case "jsonl":
+		return p.json(struct {
+			At        string `json:"at"`
+			OldOffset int    `json:"old_offset"`
+			NewOffset int    `json:"new_offset"`
+			OldName   string `json:"old_name"`
+			NewName   string `json:"new_name"`
+			Kind      string `json:"kind"`
+		}{at, tr.OldOffset, tr.NewOffset, tr.OldName, tr.NewName, kind})
+	case "csv":
+		return p.row([]string{"at", "old_offset", "new_offset", "old_name", "new_name", "kind"},
+			[]string{at, strconv.Itoa(tr.OldOffset), strconv.Itoa(tr.NewOffset), tr.OldName, tr.NewName, kind})
+	}
+	_, err := fmt.Fprintf(p.w, "%s %s(%+d)->%s(%+d) %s\n", at, tr.OldName, tr.OldOffset, tr.NewName, tr.NewOffset, kind)
+	return err
+}
+
+func (p *printer) json(v interface{}) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(p.w, "%s\n", b)
+	return err
+}
+
+// row writes a csv record, preceded by the header on the first call
+func (p *printer) row(header, record []string) error {
+	if !p.header {
+		p.header = true
+		if err := p.csv.Write(header); err != nil {
+			return err
+		}
+	}
+	if err := p.csv.Write(record); err != nil {
+		return err
+	}
+	p.csv.Flush()
+	return p.csv.Error()
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/daneroo/timewalker"
+)
+
+// runFloor prints each time argument rounded down to a boundary of --by
+func runFloor(args []string, stdout, stderr io.Writer) error {
+	return roundTimes("floor", timewalker.Duration.Floor, args, stdout, stderr)
+}
+
+// runCeil prints each time argument rounded up to a boundary of --by
+func runCeil(args []string, stdout, stderr io.Writer) error {
+	return roundTimes("ceil", timewalker.Duration.Ceil, args, stdout, stderr)
+}
+
+func roundTimes(name string, round func(timewalker.Duration, time.Time) time.Time, args []string, stdout, stderr io.Writer) error {
+	var o options
+	fs := newFlagSet(name, &o, stderr)
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		return fmt.Errorf("%s: missing time argument", name)
+	}
+	d, loc, p, err := o.resolve(stdout)
+	if err != nil {
+		return err
+	}
+	for _, arg := range fs.Args() {
+		t, err := parseTime(arg, loc)
+		if err != nil {
+			return err
+		}
+		if err := p.time(round(d, t)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// runRound prints the interval from --from to --to, rounded outward to boundaries of --by, see timewalker.Interval.Round
+func runRound(args []string, stdout, stderr io.Writer) error {
+	var o options
+	var from, to string
+	fs := newFlagSet("round", &o, stderr)
+	fs.StringVar(&from, "from", "", "start `time` of the interval")
+	fs.StringVar(&to, "to", "", "end `time` of the interval, excluded")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	d, loc, p, err := o.resolve(stdout)
+	if err != nil {
+		return err
+	}
+	i, err := parseInterval(from, to, loc)
+	if err != nil {
+		return err
+	}
+	if i, err = i.Round(d); err != nil {
+		return err
+	}
+	return p.interval(i)
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+
+	"github.com/daneroo/timewalker/httpapi"
+)
+
+// runServe serves the HTTP/JSON API of package httpapi on --addr
+func runServe(args []string, stdout, stderr io.Writer) error {
+	var addr string
+	fs := flag.NewFlagSet("serve", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.StringVar(&addr, "addr", "localhost:8080", "`address` to listen on")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(stderr, "timewalker: serving on http://%s\n", ln.Addr())
+	return http.Serve(ln, httpapi.NewHandler())
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"io"
+
+	"github.com/daneroo/timewalker"
+)
+
+// runWalk prints the intervals of --by from --from (incl) to --to (excl), or only their starts with --instants
+func runWalk(args []string, stdout, stderr io.Writer) error {
+	var o options
+	var from, to string
+	var instants bool
+	fs := newFlagSet("walk", &o, stderr)
+	fs.StringVar(&from, "from", "", "start `time` of the walk")
+	fs.StringVar(&to, "to", "", "end `time` of the walk, excluded")
+	fs.BoolVar(&instants, "instants", false, "print the start of each interval only")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	d, loc, p, err := o.resolve(stdout)
+	if err != nil {
+		return err
+	}
+	i, err := parseInterval(from, to, loc)
+	if err != nil {
+		return err
+	}
+	ch, err := i.Walk(d)
+	if err != nil {
+		return err
+	}
+	return drain(ch, func(i timewalker.Interval) error {
+		if instants {
+			return p.time(i.Start)
+		}
+		return p.interval(i)
+	})
+}
+
+// drain prints every Interval of ch, and keeps draining after an error, so that the walking goroutine ends
+func drain(ch <-chan timewalker.Interval, print func(timewalker.Interval) error) error {
+	var err error
+	for i := range ch {
+		if err == nil {
+			err = print(i)
+		}
+	}
+	return err
+}
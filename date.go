@@ -0,0 +1,165 @@
+package timewalker
+
+import (
+	"fmt"
+	"time"
+)
+
+// Date represents a civil calendar date (year, month, day), independent of any time.Location.
+// Unlike a time.Time at midnight, a Date has no instant, so it cannot drift by a day when data crosses zones.
+type Date struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+// DateOf returns the Date of t, in t's Location
+func DateOf(t time.Time) Date {
+	year, month, day := t.Date()
+	return Date{Year: year, Month: month, Day: day}
+}
+
+// ParseDate parses a Date in the ISO 8601 (RFC 3339 full-date) format, e.g. 2001-02-03
+func ParseDate(s string) (Date, error) {
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		return Date{}, fmt.Errorf("invalid Date %q: %v", s, err)
+	}
+	return DateOf(t), nil
+}
+
+// String formats the Date in the ISO 8601 format, e.g. 2001-02-03
+func (d Date) String() string {
+	return d.utc().Format("2006-01-02")
+}
+
+// IsValid reports whether the Date exists in the calendar, e.g. 2001-02-29 does not
+func (d Date) IsValid() bool {
+	return DateOf(d.utc()) == d
+}
+
+// In returns the instant at which the Date starts in the given Location, i.e. midnight, or the earliest time of that day
+// when midnight does not exist because of a daylight savings transition, e.g. 01:00 on 2018-11-04 in America/Sao_Paulo.
+// Unlike time.Date, which would return 23:00 on the previous day, the instant is always within the Date.
+func (d Date) In(loc *time.Location) time.Time {
+	// midnight shifted forward by the gap is the first instant of the day
+	t, _ := DateTime{Date: d}.In(loc, DisambiguateShiftForward)
+	return t
+}
+
+// AddDays returns the Date n days after the receiver, n may be negative
+func (d Date) AddDays(n int) Date {
+	return d.AddDate(0, 0, n)
+}
+
+// AddDate returns the Date obtained by adding years, months and days to the receiver, normalizing as time.AddDate does
+func (d Date) AddDate(years, months, days int) Date {
+	return DateOf(d.utc().AddDate(years, months, days))
+}
+
+// Sub returns the number of days from e to the receiver, i.e. d == e.AddDays(d.Sub(e))
+func (d Date) Sub(e Date) int {
+	// from Unix seconds, as a time.Duration would saturate after about 292 years
+	return int((d.utc().Unix() - e.utc().Unix()) / (24 * 60 * 60))
+}
+
+// Before reports whether the receiver is before e
+func (d Date) Before(e Date) bool {
+	return d.utc().Before(e.utc())
+}
+
+// After reports whether the receiver is after e
+func (d Date) After(e Date) bool {
+	return d.utc().After(e.utc())
+}
+
+// Weekday returns the day of the week of the receiver
+func (d Date) Weekday() time.Weekday {
+	return d.utc().Weekday()
+}
+
+// utc returns the receiver as midnight UTC, where all days have 24 hours, to delegate calendar arithmetic to time.Time
+func (d Date) utc() time.Time {
+	return time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, time.UTC)
+}
+
+// FloorDate returns the greatest Date that is on receivers Duration boundary; like Floor for a time.Time
+func (dur Duration) FloorDate(d Date) Date {
+	return DateOf(dur.Floor(d.utc()))
+}
+
+// CeilDate returns the least Date that is on receivers Duration boundary; like Ceil for a time.Time
+func (dur Duration) CeilDate(d Date) Date {
+	return DateOf(dur.Ceil(d.utc()))
+}
+
+// AddToDate returns a new Date by adding the receiver's duration to the passed Date; like AddTo for a time.Time
+func (dur Duration) AddToDate(d Date) Date {
+	return DateOf(dur.AddTo(d.utc()))
+}
+
+// WalkDates produces Dates from a (incl) to b (excl); like Walk for a time.Time
+func WalkDates(a, b Date, d Duration) (<-chan Date, error) {
+	ch, err := Walk(a.utc(), b.utc(), d)
+	if err != nil {
+		return nil, err
+	}
+	dates := make(chan Date)
+	go func() {
+		for t := range ch {
+			dates <- DateOf(t)
+		}
+		close(dates)
+	}()
+	return dates, nil
+}
+
+// DateInterval represents an interval of Dates from [Start,End), independent of any time.Location
+type DateInterval struct {
+	Start Date
+	End   Date
+}
+
+func (i DateInterval) String() string {
+	return fmt.Sprintf("[%s, %s)", i.Start, i.End)
+}
+
+// Days returns the number of days in the interval
+func (i DateInterval) Days() int {
+	return i.End.Sub(i.Start)
+}
+
+// In returns the Interval of instants covered by the receiver in the given Location, from the start of Start to the start of End.
+// This is where days of 23 or 25 hours appear, on daylight savings boundaries.
+func (i DateInterval) In(loc *time.Location) Interval {
+	return Interval{Start: i.Start.In(loc), End: i.End.In(loc)}
+}
+
+// Round normalizes the DateInterval like Interval.Round: Start and End are swapped if needed, and rounded to the Duration boundaries,
+// with at least one Duration in the result
+func (i DateInterval) Round(d Duration) DateInterval {
+	// dates are in UTC, so rounding the equivalent Interval cannot fail
+	ri, _ := i.utc().Round(d)
+	return DateInterval{Start: DateOf(ri.Start), End: DateOf(ri.End)}
+}
+
+// Walk traverses the receiver's interval in steps of the given duration; like Interval.Walk
+func (i DateInterval) Walk(d Duration) (<-chan DateInterval, error) {
+	ch, err := i.utc().Walk(d)
+	if err != nil {
+		return nil, err
+	}
+	dates := make(chan DateInterval)
+	go func() {
+		for ri := range ch {
+			dates <- DateInterval{Start: DateOf(ri.Start), End: DateOf(ri.End)}
+		}
+		close(dates)
+	}()
+	return dates, nil
+}
+
+// utc returns the receiver as an Interval in UTC, where all days have 24 hours
+func (i DateInterval) utc() Interval {
+	return i.In(time.UTC)
+}
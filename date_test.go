@@ -0,0 +1,132 @@
+package timewalker
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func parseDate(s string) Date {
+	d, err := ParseDate(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+func TestDate(t *testing.T) {
+	d := parseDate("2004-02-28")
+	if d != (Date{Year: 2004, Month: time.February, Day: 28}) || d.String() != "2004-02-28" {
+		t.Errorf("ParseDate(2004-02-28): %#v", d)
+	}
+	if !d.IsValid() || (Date{2001, time.February, 29}).IsValid() {
+		t.Error("IsValid: unexpected result")
+	}
+	if next := d.AddDays(1); next != parseDate("2004-02-29") || !next.After(d) || next.Before(d) {
+		t.Errorf("AddDays(1): %v", next)
+	}
+	if actual := parseDate("2005-03-01").Sub(d); actual != 367 {
+		t.Errorf("Sub: exp: 367 act: %d", actual)
+	}
+	// beyond the range of a time.Duration
+	far, near := parseDate("2500-01-01"), parseDate("2000-01-01")
+	if actual := far.Sub(near); actual != 182622 || near.AddDays(actual) != far || near.Sub(far) != -182622 {
+		t.Errorf("Sub: exp: 182622 act: %d", actual)
+	}
+	if days := (DateInterval{Start: near, End: far}).Days(); days != 182622 {
+		t.Errorf("Days: exp: 182622 act: %d", days)
+	}
+	if d.Weekday() != time.Saturday {
+		t.Errorf("Weekday: exp: Saturday act: %v", d.Weekday())
+	}
+	if _, err := ParseDate("2004-02-30"); err == nil {
+		t.Error("ParseDate(2004-02-30): expected an error")
+	}
+
+	// the Date of a time.Time depends on its Location, not a Date
+	loc, _ := time.LoadLocation("Asia/Tokyo")
+	inst := parseTime("2004-02-28T20:00:00Z")
+	if DateOf(inst) != d || DateOf(inst.In(loc)) != d.AddDays(1) {
+		t.Errorf("DateOf(%v): unexpected result", inst)
+	}
+}
+
+func TestDateInGap(t *testing.T) {
+	// in Sao Paulo, clocks sprang forward at midnight: 2018-11-04 starts at 01:00
+	loc, _ := time.LoadLocation("America/Sao_Paulo")
+	d := parseDate("2018-11-04")
+	start := d.In(loc)
+	if DateOf(start) != d || start.Hour() != 1 || !start.Equal(parseTime("2018-11-04T03:00:00Z")) {
+		t.Errorf("%v.In(%v): \nexp: 2018-11-04 01:00:00 -0200, \nact: %v", d, loc, start)
+	}
+	i := DateInterval{Start: parseDate("2018-11-03"), End: parseDate("2018-11-05")}.In(loc)
+	exp := Interval{Start: parseTime("2018-11-03T03:00:00Z"), End: parseTime("2018-11-05T02:00:00Z")}
+	if !i.Start.Equal(exp.Start) || !i.End.Equal(exp.End) {
+		t.Errorf("DateInterval.In(%v): \nexp: %v, \nact: %v", loc, exp, i)
+	}
+	// an ordinary day starts at midnight
+	if start := parseDate("2018-11-05").In(loc); start.Hour() != 0 || DateOf(start) != parseDate("2018-11-05") {
+		t.Errorf("2018-11-05.In(%v): %v", loc, start)
+	}
+}
+
+func TestDurationDate(t *testing.T) {
+	var testData = []struct {
+		inp              string   // input
+		dur              Duration // duration
+		floor, ceil, add string   // expected results
+	}{
+		{"2004-02-29", Day, "2004-02-29", "2004-02-29", "2004-03-01"},
+		{"2004-02-29", Month, "2004-02-01", "2004-03-01", "2004-03-29"},
+		{"2004-02-29", Year, "2004-01-01", "2005-01-01", "2005-03-01"},
+		{"2004-01-01", Year, "2004-01-01", "2004-01-01", "2005-01-01"},
+	}
+	for _, tt := range testData {
+		d := parseDate(tt.inp)
+		if actual := tt.dur.FloorDate(d).String(); actual != tt.floor {
+			t.Errorf("%s.FloorDate(%s): exp: %s act: %s", tt.dur, d, tt.floor, actual)
+		}
+		if actual := tt.dur.CeilDate(d).String(); actual != tt.ceil {
+			t.Errorf("%s.CeilDate(%s): exp: %s act: %s", tt.dur, d, tt.ceil, actual)
+		}
+		if actual := tt.dur.AddToDate(d).String(); actual != tt.add {
+			t.Errorf("%s.AddToDate(%s): exp: %s act: %s", tt.dur, d, tt.add, actual)
+		}
+	}
+}
+
+func TestDateIntervalRound(t *testing.T) {
+	i := DateInterval{Start: parseDate("2004-03-15"), End: parseDate("2004-02-15")}
+	exp := DateInterval{Start: parseDate("2004-02-01"), End: parseDate("2004-04-01")}
+	if actual := i.Round(Month); actual != exp {
+		t.Errorf("%v.Round(Month): exp: %v act: %v", i, exp, actual)
+	}
+	if days := exp.Days(); days != 60 {
+		t.Errorf("%v.Days(): exp: 60 act: %d", exp, days)
+	}
+}
+
+func ExampleWalkDates() {
+	ch, _ := WalkDates(parseDate("2004-02-27"), parseDate("2004-03-02"), Day)
+	for d := range ch {
+		fmt.Println(d, d.Weekday())
+	}
+	// Output:
+	// 2004-02-27 Friday
+	// 2004-02-28 Saturday
+	// 2004-02-29 Sunday
+	// 2004-03-01 Monday
+}
+
+func ExampleDateInterval_In() {
+	loc, _ := time.LoadLocation("America/Montreal")
+	i := DateInterval{Start: parseDate("2008-03-08"), End: parseDate("2008-03-11")}
+	ch, _ := i.Walk(Day)
+	for d := range ch {
+		fmt.Printf("%v has %.0f hours\n", d.Start, d.In(loc).End.Sub(d.In(loc).Start).Hours())
+	}
+	// Output:
+	// 2008-03-08 has 24 hours
+	// 2008-03-09 has 23 hours
+	// 2008-03-10 has 24 hours
+}
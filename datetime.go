@@ -0,0 +1,159 @@
+package timewalker
+
+import (
+	"fmt"
+	"time"
+)
+
+// DateTime represents a civil (wall clock) date and time, without a Location, e.g. 2008-11-02 01:30 as read on a clock in Montreal.
+// In a given Location, a DateTime may be ambiguous (it happens twice, when clocks fall back) or nonexistent (it is skipped, when clocks spring forward).
+type DateTime struct {
+	Date
+	Hour       int
+	Minute     int
+	Second     int
+	Nanosecond int
+}
+
+// Disambiguation defines how a DateTime is resolved to an instant when it is ambiguous or nonexistent in a Location
+type Disambiguation int
+
+// Different package constants defining an enum type for Disambiguation
+const (
+	// DisambiguateEarlier picks the earlier of two instants for an ambiguous DateTime,
+	// and shifts a nonexistent one backward by the length of the gap, e.g. 02:30 becomes 01:30 when clocks spring forward at 02:00
+	DisambiguateEarlier Disambiguation = iota
+	// DisambiguateLater picks the later of two instants for an ambiguous DateTime,
+	// and shifts a nonexistent one forward by the length of the gap, e.g. 02:30 becomes 03:30 when clocks spring forward at 02:00
+	DisambiguateLater
+	// DisambiguateError returns an error for an ambiguous or nonexistent DateTime
+	DisambiguateError
+	// DisambiguateShiftForward picks the earlier instant for an ambiguous DateTime, and shifts a nonexistent one forward by the length of the gap.
+	// This is the usual behavior of calendar applications.
+	DisambiguateShiftForward
+)
+
+// Produces Human readable representations of the Disambiguation enum values
+func (p Disambiguation) String() string {
+	str := "Invalid"
+	switch p {
+	case DisambiguateEarlier:
+		str = "Earlier"
+	case DisambiguateLater:
+		str = "Later"
+	case DisambiguateError:
+		str = "Error"
+	case DisambiguateShiftForward:
+		str = "ShiftForward"
+	}
+	return str
+}
+
+// DateTimeOf returns the wall clock DateTime of t, in t's Location
+func DateTimeOf(t time.Time) DateTime {
+	hour, min, sec := t.Clock()
+	return DateTime{Date: DateOf(t), Hour: hour, Minute: min, Second: sec, Nanosecond: t.Nanosecond()}
+}
+
+// ParseDateTime parses a DateTime in the ISO 8601 format without offset, e.g. 2008-11-02T01:30:00, with optional fractional seconds
+func ParseDateTime(s string) (DateTime, error) {
+	t, err := time.Parse("2006-01-02T15:04:05.999999999", s)
+	if err != nil {
+		return DateTime{}, fmt.Errorf("invalid DateTime %q: %v", s, err)
+	}
+	return DateTimeOf(t), nil
+}
+
+// String formats the DateTime in the ISO 8601 format without offset, e.g. 2008-11-02T01:30:00
+func (dt DateTime) String() string {
+	return dt.utc().Format("2006-01-02T15:04:05.999999999")
+}
+
+// Add returns the DateTime obtained by adding the Period to the receiver on the wall clock, as if every day had 24 hours
+func (dt DateTime) Add(p Period) DateTime {
+	return DateTimeOf(p.AddTo(dt.utc()))
+}
+
+// Before reports whether the receiver is before e on the wall clock
+func (dt DateTime) Before(e DateTime) bool {
+	return dt.utc().Before(e.utc())
+}
+
+// utc returns the receiver's wall clock in UTC, where there are no daylight savings transitions
+func (dt DateTime) utc() time.Time {
+	return time.Date(dt.Year, dt.Month, dt.Day, dt.Hour, dt.Minute, dt.Second, dt.Nanosecond, time.UTC)
+}
+
+// In resolves the receiver to an instant in the given Location, using the Disambiguation policy when the wall clock is ambiguous or nonexistent.
+// This makes explicit what time.Date, and so Duration.Floor, does implicitly.
+func (dt DateTime) In(loc *time.Location, p Disambiguation) (time.Time, error) {
+	wall := dt.utc()
+	// the offsets in effect a day before and after, assuming at most one transition in between
+	_, before := wall.Add(-24 * time.Hour).In(loc).Zone()
+	_, after := wall.Add(24 * time.Hour).In(loc).Zone()
+
+	var candidates []time.Time
+	for _, offset := range []int{before, after} {
+		t := wall.Add(-time.Duration(offset) * time.Second).In(loc)
+		if _, o := t.Zone(); o == offset && (len(candidates) == 0 || !candidates[0].Equal(t)) {
+			candidates = append(candidates, t)
+		}
+	}
+
+	switch len(candidates) {
+	case 1:
+		return candidates[0], nil
+	case 2: // ambiguous, clocks fell back
+		earlier, later := candidates[0], candidates[1]
+		if later.Before(earlier) {
+			earlier, later = later, earlier
+		}
+		switch p {
+		case DisambiguateEarlier, DisambiguateShiftForward:
+			return earlier, nil
+		case DisambiguateLater:
+			return later, nil
+		case DisambiguateError:
+			return time.Time{}, fmt.Errorf("ambiguous DateTime %v in %v", dt, loc)
+		}
+	default: // nonexistent, clocks sprang forward
+		switch p {
+		case DisambiguateEarlier:
+			// read with the offset after the gap, i.e. shifted backward by the gap
+			return wall.Add(-time.Duration(after) * time.Second).In(loc), nil
+		case DisambiguateLater, DisambiguateShiftForward:
+			// read with the offset before the gap, i.e. shifted forward by the gap
+			return wall.Add(-time.Duration(before) * time.Second).In(loc), nil
+		case DisambiguateError:
+			return time.Time{}, fmt.Errorf("nonexistent DateTime %v in %v", dt, loc)
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid Disambiguation policy: %v", p)
+}
+
+// WalkDateTimes steps on the wall clock from a (incl) to b (excl) by the given Period, then resolves each step to an instant in loc with the Disambiguation policy.
+// Steps which do not resolve to an instant after the previous one, e.g. a nonexistent DateTime shifted onto a following step, are skipped,
+// so the produced times are strictly increasing.
+// DisambiguateError is not accepted, as the error could only be detected while walking.
+func WalkDateTimes(a, b DateTime, p Period, loc *time.Location, policy Disambiguation) (<-chan time.Time, error) {
+	if policy == DisambiguateError || policy < DisambiguateEarlier || policy > DisambiguateShiftForward {
+		return nil, fmt.Errorf("invalid Disambiguation policy for walking: %v", policy)
+	}
+	if !a.Add(p).utc().After(a.utc()) {
+		return nil, fmt.Errorf("period must be positive: %v", p)
+	}
+	ch := make(chan time.Time)
+	go func() {
+		var last time.Time
+		for k, dt := 0, a; dt.Before(b); k++ {
+			t, _ := dt.In(loc, policy)
+			if k == 0 || t.After(last) {
+				ch <- t
+				last = t
+			}
+			dt = a.Add(p.times(k + 1))
+		}
+		close(ch)
+	}()
+	return ch, nil
+}
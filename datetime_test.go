@@ -0,0 +1,97 @@
+package timewalker
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func parseDateTime(s string) DateTime {
+	dt, err := ParseDateTime(s)
+	if err != nil {
+		panic(err)
+	}
+	return dt
+}
+
+func TestDateTimeIn(t *testing.T) {
+	loc, _ := time.LoadLocation("America/Montreal")
+	var testData = []struct {
+		inp    string         // civil datetime in Montreal
+		policy Disambiguation // policy
+		exp    string         // expected instant, or "" for an error
+	}{
+		// unambiguous
+		{"2008-07-01T12:00:00", DisambiguateError, "2008-07-01T12:00:00-04:00"},
+		{"2008-01-01T12:00:00", DisambiguateError, "2008-01-01T12:00:00-05:00"},
+		// ambiguous: 01:00-02:00 happens twice on 2008-11-02
+		{"2008-11-02T01:30:00", DisambiguateEarlier, "2008-11-02T01:30:00-04:00"},
+		{"2008-11-02T01:30:00", DisambiguateLater, "2008-11-02T01:30:00-05:00"},
+		{"2008-11-02T01:30:00", DisambiguateShiftForward, "2008-11-02T01:30:00-04:00"},
+		{"2008-11-02T01:30:00", DisambiguateError, ""},
+		{"2008-11-02T02:00:00", DisambiguateError, "2008-11-02T02:00:00-05:00"},
+		// nonexistent: 02:00-03:00 is skipped on 2008-03-09
+		{"2008-03-09T02:30:00", DisambiguateEarlier, "2008-03-09T01:30:00-05:00"},
+		{"2008-03-09T02:30:00", DisambiguateLater, "2008-03-09T03:30:00-04:00"},
+		{"2008-03-09T02:30:00", DisambiguateShiftForward, "2008-03-09T03:30:00-04:00"},
+		{"2008-03-09T02:30:00", DisambiguateError, ""},
+		{"2008-03-09T03:00:00", DisambiguateError, "2008-03-09T03:00:00-04:00"},
+		{"2008-03-09T01:59:59.5", DisambiguateError, "2008-03-09T01:59:59.5-05:00"},
+	}
+	for _, tt := range testData {
+		dt := parseDateTime(tt.inp)
+		actual, err := dt.In(loc, tt.policy)
+		if tt.exp == "" {
+			if err == nil {
+				t.Errorf("%v.In(%s): expected an error, got %v", dt, tt.policy, actual)
+			}
+			continue
+		}
+		if err != nil || !actual.Equal(parseTime(tt.exp)) || actual.Location() != loc {
+			t.Errorf("%v.In(%s): \nexp: %v, \nact: %v, %v", dt, tt.policy, tt.exp, actual, err)
+		}
+	}
+	if _, err := parseDateTime("2008-11-02T01:30:00").In(loc, Disambiguation(42)); err == nil {
+		t.Error("Expected error for invalid Disambiguation")
+	}
+}
+
+func TestDateTimeString(t *testing.T) {
+	for _, s := range []string{"2008-11-02T01:30:00", "2008-11-02T01:30:00.25"} {
+		if actual := parseDateTime(s).String(); actual != s {
+			t.Errorf("ParseDateTime(%s).String(): %s", s, actual)
+		}
+	}
+	if _, err := ParseDateTime("2008-11-02T01:30:00-04:00"); err == nil {
+		t.Error("ParseDateTime: expected an error for an offset")
+	}
+}
+
+func ExampleWalkDateTimes() {
+	// every 30 minutes, on the wall clock, across the spring forward gap
+	loc, _ := time.LoadLocation("America/Montreal")
+	p, _ := ParsePeriod("PT30M")
+	a, b := parseDateTime("2008-03-09T01:00:00"), parseDateTime("2008-03-09T04:00:00")
+	ch, _ := WalkDateTimes(a, b, p, loc, DisambiguateShiftForward)
+	for t := range ch {
+		fmt.Println(t)
+	}
+	// Output:
+	// 2008-03-09 01:00:00 -0500 EST
+	// 2008-03-09 01:30:00 -0500 EST
+	// 2008-03-09 03:00:00 -0400 EDT
+	// 2008-03-09 03:30:00 -0400 EDT
+}
+
+func ExampleDateTime_In() {
+	loc, _ := time.LoadLocation("America/Montreal")
+	dt := parseDateTime("2008-11-02T01:30:00")
+	for _, p := range []Disambiguation{DisambiguateEarlier, DisambiguateLater, DisambiguateError} {
+		t, err := dt.In(loc, p)
+		fmt.Println(p, t, err)
+	}
+	// Output:
+	// Earlier 2008-11-02 01:30:00 -0400 EDT <nil>
+	// Later 2008-11-02 01:30:00 -0500 EST <nil>
+	// Error 0001-01-01 00:00:00 +0000 UTC ambiguous DateTime 2008-11-02T01:30:00 in America/Montreal
+}
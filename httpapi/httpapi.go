@@ -0,0 +1,319 @@
+// Package httpapi exposes timewalker's rounding, walking and DST transitions as an HTTP/JSON service,
+// so that services written in other languages share the same DST-aware bucketing.
+//
+// Every endpoint takes its arguments as query parameters: tz (a zone name, UTC by default), unit (day, week, month, quarter or year, day by default),
+// and times given as dates (2020-01-01), wall clock times (2020-01-01T12:00:00), both read in tz, or RFC 3339 instants.
+//
+//	GET /floor?t=2020-03-14T15:09:26Z&unit=month&tz=America/Montreal
+//	GET /ceil?t=2020-03-14T15:09:26Z&unit=month&tz=America/Montreal
+//	GET /round?start=2020-01-15&end=2020-03-14&unit=month
+//	GET /walk?start=2020-01-01&end=2021-01-01&unit=day&limit=100
+//	GET /dst?start=2020-01-01&end=2021-01-01&tz=America/Montreal
+//
+// Responses are JSON objects, or NDJSON streams, one object per line, for /walk and /dst when requested with format=ndjson
+// or an Accept: application/x-ndjson header. Walks are paginated: at most limit intervals are returned, and the next page,
+// if any, is given by the next field of the JSON response, and by a Link header with rel="next".
+// Errors are returned as {"error": "..."} with a 4xx status.
+package httpapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/daneroo/timewalker"
+)
+
+// DefaultLimit and MaxLimit bound the number of intervals of a page of /walk
+const (
+	DefaultLimit = 1000
+	MaxLimit     = 10000
+)
+
+// NewHandler returns the http.Handler serving the endpoints
+func NewHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/floor", get(func(w http.ResponseWriter, r *http.Request) error {
+		return roundTime(w, r, timewalker.Duration.Floor)
+	}))
+	mux.HandleFunc("/ceil", get(func(w http.ResponseWriter, r *http.Request) error {
+		return roundTime(w, r, timewalker.Duration.Ceil)
+	}))
+	mux.HandleFunc("/round", get(round))
+	mux.HandleFunc("/walk", get(walk))
+	mux.HandleFunc("/dst", get(dst))
+	return mux
+}
+
+// Time is the JSON representation of an instant
+type Time struct {
+	Time string `json:"time"`
+}
+
+// Interval is the JSON representation of an Interval
+type Interval struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+// Page is the JSON representation of a page of a walk
+type Page struct {
+	Intervals []Interval `json:"intervals"`
+	// Next is the URL of the next page, if any
+	Next string `json:"next,omitempty"`
+}
+
+// Transition is the JSON representation of a Transition
+type Transition struct {
+	At        string `json:"at"`
+	OldOffset int    `json:"old_offset"`
+	NewOffset int    `json:"new_offset"`
+	OldName   string `json:"old_name"`
+	NewName   string `json:"new_name"`
+	// Kind is gap, overlap, or offset for a change of abbreviation only
+	Kind string `json:"kind"`
+}
+
+// badRequest is an error caused by the request, reported with http.StatusBadRequest
+type badRequest struct {
+	err error
+}
+
+func (e badRequest) Error() string {
+	return e.err.Error()
+}
+
+func badRequestf(format string, args ...interface{}) error {
+	return badRequest{fmt.Errorf(format, args...)}
+}
+
+// get adapts a handler returning an error to an http.HandlerFunc accepting GET requests only
+func get(h func(w http.ResponseWriter, r *http.Request) error) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %s not allowed", r.Method))
+			return
+		}
+		if err := h(w, r); err != nil {
+			status := http.StatusInternalServerError
+			if _, ok := err.(badRequest); ok {
+				status = http.StatusBadRequest
+			}
+			writeError(w, status, err)
+		}
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(struct {
+		Error string `json:"error"`
+	}{err.Error()})
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) error {
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(v)
+}
+
+// params are the parsed query parameters shared by the endpoints
+type params struct {
+	query url.Values
+	unit  timewalker.Duration
+	loc   *time.Location
+}
+
+func parseParams(r *http.Request) (params, error) {
+	p := params{query: r.URL.Query(), unit: timewalker.Day, loc: time.UTC}
+	if unit := p.query.Get("unit"); unit != "" {
+		d, err := timewalker.ParseDuration(unit)
+		if err != nil {
+			return p, badRequest{err}
+		}
+		p.unit = d
+	}
+	if tz := p.query.Get("tz"); tz != "" {
+		loc, err := timewalker.LoadZone(tz)
+		if err != nil {
+			return p, badRequest{err}
+		}
+		p.loc = loc
+	}
+	return p, nil
+}
+
+// time parses the named query parameter in p.loc, see timewalker.ParseTimeIn
+func (p params) time(name string) (time.Time, error) {
+	s := p.query.Get(name)
+	if s == "" {
+		return time.Time{}, badRequestf("missing parameter %s", name)
+	}
+	t, err := timewalker.ParseTimeIn(s, p.loc)
+	if err != nil {
+		return t, badRequestf("invalid %s %q: expected a date, a wall clock time or an RFC 3339 instant", name, s)
+	}
+	return t, nil
+}
+
+// interval parses the start and end query parameters
+func (p params) interval() (timewalker.Interval, error) {
+	start, err := p.time("start")
+	if err != nil {
+		return timewalker.Interval{}, err
+	}
+	end, err := p.time("end")
+	if err != nil {
+		return timewalker.Interval{}, err
+	}
+	return timewalker.Interval{Start: start, End: end}, nil
+}
+
+// ndjson reports whether the response should be an NDJSON stream
+func ndjson(r *http.Request) bool {
+	return r.URL.Query().Get("format") == "ndjson" || strings.Contains(r.Header.Get("Accept"), "application/x-ndjson")
+}
+
+func formatTime(t time.Time) string {
+	return t.Format(time.RFC3339Nano)
+}
+
+func roundTime(w http.ResponseWriter, r *http.Request, round func(timewalker.Duration, time.Time) time.Time) error {
+	p, err := parseParams(r)
+	if err != nil {
+		return err
+	}
+	t, err := p.time("t")
+	if err != nil {
+		return err
+	}
+	return writeJSON(w, Time{formatTime(round(p.unit, t))})
+}
+
+func round(w http.ResponseWriter, r *http.Request) error {
+	p, err := parseParams(r)
+	if err != nil {
+		return err
+	}
+	i, err := p.interval()
+	if err != nil {
+		return err
+	}
+	if i, err = i.Round(p.unit); err != nil {
+		return badRequest{err}
+	}
+	return writeJSON(w, Interval{formatTime(i.Start), formatTime(i.End)})
+}
+
+// walk serves a page of the walk from start, or from the after parameter of a following page, to end
+func walk(w http.ResponseWriter, r *http.Request) error {
+	p, err := parseParams(r)
+	if err != nil {
+		return err
+	}
+	i, err := p.interval()
+	if err != nil {
+		return err
+	}
+	limit := DefaultLimit
+	if s := p.query.Get("limit"); s != "" {
+		if limit, err = strconv.Atoi(s); err != nil || limit < 1 || limit > MaxLimit {
+			return badRequestf("invalid limit %q: expected a number from 1 to %d", s, MaxLimit)
+		}
+	}
+	if i, err = i.Round(p.unit); err != nil {
+		return badRequest{err}
+	}
+	if p.query.Get("after") != "" {
+		after, err := p.time("after")
+		if err != nil {
+			return err
+		}
+		if !p.unit.Floor(after).Equal(after) || after.Before(i.Start) {
+			return badRequestf("invalid after %q: not a %v boundary of the walk", p.query.Get("after"), p.unit)
+		}
+		i.Start = after
+	}
+
+	// the walk is paginated, so it is stepped here rather than with Interval.Walk, which would run ahead of the page
+	page := Page{Intervals: []Interval{}}
+	start := i.Start
+	for start.Before(i.End) && len(page.Intervals) < limit {
+		end := p.unit.AddTo(start)
+		page.Intervals = append(page.Intervals, Interval{formatTime(start), formatTime(end)})
+		start = end
+	}
+	if start.Before(i.End) {
+		next := *r.URL
+		query := next.Query()
+		query.Set("after", formatTime(start))
+		next.RawQuery = query.Encode()
+		page.Next = next.String()
+		w.Header().Set("Link", fmt.Sprintf("<%s>; rel=\"next\"", page.Next))
+	}
+
+	if !ndjson(r) {
+		return writeJSON(w, page)
+	}
+	items := make([]interface{}, len(page.Intervals))
+	for k, i := range page.Intervals {
+		items[k] = i
+	}
+	return writeNDJSON(w, items)
+}
+
+func dst(w http.ResponseWriter, r *http.Request) error {
+	p, err := parseParams(r)
+	if err != nil {
+		return err
+	}
+	i, err := p.interval()
+	if err != nil {
+		return err
+	}
+	transitions, err := timewalker.Transitions(p.loc, i)
+	if err != nil {
+		return badRequest{err}
+	}
+	items := make([]Transition, len(transitions))
+	for k, tr := range transitions {
+		kind := "offset"
+		if tr.IsGap() {
+			kind = "gap"
+		} else if tr.IsOverlap() {
+			kind = "overlap"
+		}
+		items[k] = Transition{formatTime(tr.At), tr.OldOffset, tr.NewOffset, tr.OldName, tr.NewName, kind}
+	}
+	if !ndjson(r) {
+		return writeJSON(w, struct {
+			Transitions []Transition `json:"transitions"`
+		}{items})
+	}
+	stream := make([]interface{}, len(items))
+	for k, tr := range items {
+		stream[k] = tr
+	}
+	return writeNDJSON(w, stream)
+}
+
+// writeNDJSON writes one JSON object per line, flushing each line
+func writeNDJSON(w http.ResponseWriter, items []interface{}) error {
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	enc := json.NewEncoder(w)
+	flusher, _ := w.(http.Flusher)
+	for _, item := range items {
+		if err := enc.Encode(item); err != nil {
+			return err
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+	}
+	return nil
+}
@@ -0,0 +1,148 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func get200(t *testing.T, h http.Handler, target string, header ...string) *http.Response {
+	t.Helper()
+	req := httptest.NewRequest("GET", target, nil)
+	for k := 0; k+1 < len(header); k += 2 {
+		req.Header.Set(header[k], header[k+1])
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET %s: status %d: %s", target, rec.Code, rec.Body)
+	}
+	return rec.Result()
+}
+
+func body(res *http.Response) string {
+	b, _ := ioutil.ReadAll(res.Body)
+	return string(b)
+}
+
+func TestHandler(t *testing.T) {
+	h := NewHandler()
+	var testData = []struct {
+		target string // request URL
+		exp    string // expected body
+	}{
+		{
+			"/floor?t=2020-03-14T15:09:26Z&unit=month&tz=America/Montreal",
+			`{"time":"2020-03-01T00:00:00-05:00"}`,
+		}, {
+			"/ceil?t=2020-03-14T15:09:26Z&unit=month&tz=America/Montreal",
+			`{"time":"2020-04-01T00:00:00-04:00"}`,
+		}, {
+			"/floor?t=2020-03-08T02:30:00&unit=day&tz=America/Montreal",
+			`{"time":"2020-03-08T00:00:00-05:00"}`,
+		}, {
+			"/round?start=2020-01-15&end=2020-03-14&unit=month&tz=Asia/Tokyo",
+			`{"start":"2020-01-01T00:00:00+09:00","end":"2020-04-01T00:00:00+09:00"}`,
+		}, {
+			"/walk?start=2020-03-07&end=2020-03-10&tz=America/Montreal",
+			`{"intervals":[{"start":"2020-03-07T00:00:00-05:00","end":"2020-03-08T00:00:00-05:00"},` +
+				`{"start":"2020-03-08T00:00:00-05:00","end":"2020-03-09T00:00:00-04:00"},` +
+				`{"start":"2020-03-09T00:00:00-04:00","end":"2020-03-10T00:00:00-04:00"}]}`,
+		}, {
+			"/dst?start=2020-01-01&end=2021-01-01&tz=Europe/London",
+			`{"transitions":[{"at":"2020-03-29T02:00:00+01:00","old_offset":0,"new_offset":3600,"old_name":"GMT","new_name":"BST","kind":"gap"},` +
+				`{"at":"2020-10-25T01:00:00Z","old_offset":3600,"new_offset":0,"old_name":"BST","new_name":"GMT","kind":"overlap"}]}`,
+		}, {
+			"/dst?start=2020-01-01&end=2021-01-01&tz=Asia/Tokyo",
+			`{"transitions":[]}`,
+		},
+	}
+	for _, tt := range testData {
+		res := get200(t, h, tt.target)
+		if ct := res.Header.Get("Content-Type"); ct != "application/json" {
+			t.Errorf("GET %s: unexpected Content-Type %s", tt.target, ct)
+		}
+		if actual := strings.TrimSpace(body(res)); actual != tt.exp {
+			t.Errorf("GET %s: \nexp: %s, \nact: %s", tt.target, tt.exp, actual)
+		}
+	}
+}
+
+func TestHandlerNDJSON(t *testing.T) {
+	h := NewHandler()
+	exp := `{"start":"2020-01-01T00:00:00Z","end":"2020-02-01T00:00:00Z"}` + "\n" +
+		`{"start":"2020-02-01T00:00:00Z","end":"2020-03-01T00:00:00Z"}` + "\n"
+	for _, res := range []*http.Response{
+		get200(t, h, "/walk?start=2020-01-01&end=2020-03-01&unit=month&format=ndjson"),
+		get200(t, h, "/walk?start=2020-01-01&end=2020-03-01&unit=month", "Accept", "application/x-ndjson"),
+	} {
+		if ct := res.Header.Get("Content-Type"); ct != "application/x-ndjson" {
+			t.Errorf("ndjson: unexpected Content-Type %s", ct)
+		}
+		if actual := body(res); actual != exp {
+			t.Errorf("ndjson: \nexp: %s, \nact: %s", exp, actual)
+		}
+	}
+	res := get200(t, h, "/dst?start=2020-01-01&end=2021-01-01&tz=America/Montreal&format=ndjson")
+	if lines := strings.Count(body(res), "\n"); lines != 2 {
+		t.Errorf("dst ndjson: expected 2 lines, got %d", lines)
+	}
+}
+
+func TestHandlerPagination(t *testing.T) {
+	h := NewHandler()
+	target := "/walk?start=2020-01-01&end=2020-01-08&limit=3&tz=Europe/Paris"
+	var starts []string
+	for pages := 0; target != ""; pages++ {
+		if pages > 3 {
+			t.Fatalf("pagination does not end: %s", target)
+		}
+		res := get200(t, h, target)
+		var page Page
+		if err := json.NewDecoder(res.Body).Decode(&page); err != nil {
+			t.Fatalf("GET %s: %v", target, err)
+		}
+		for _, i := range page.Intervals {
+			starts = append(starts, i.Start[:10])
+		}
+		if page.Next != "" && res.Header.Get("Link") != "<"+page.Next+`>; rel="next"` {
+			t.Errorf("GET %s: unexpected Link header %q", target, res.Header.Get("Link"))
+		}
+		target = page.Next
+	}
+	exp := "2020-01-01 2020-01-02 2020-01-03 2020-01-04 2020-01-05 2020-01-06 2020-01-07"
+	if actual := strings.Join(starts, " "); actual != exp {
+		t.Errorf("pagination: \nexp: %s, \nact: %s", exp, actual)
+	}
+}
+
+func TestHandlerErrors(t *testing.T) {
+	h := NewHandler()
+	var testData = []struct {
+		method string // request method
+		target string // request URL
+		status int    // expected status
+		exp    string // expected error
+	}{
+		{"GET", "/floor?unit=month", 400, "missing parameter t"},
+		{"GET", "/floor?t=yesterday", 400, `invalid t "yesterday": expected a date, a wall clock time or an RFC 3339 instant`},
+		{"GET", "/floor?t=2020-01-01&unit=fortnight", 400, `invalid Duration: "fortnight"`},
+		{"GET", "/floor?t=2020-01-01&tz=Mars/Olympus_Mons", 400, "unknown time zone Mars/Olympus_Mons"},
+		{"GET", "/walk?start=2020-01-01&end=2020-02-01&limit=0", 400, `invalid limit "0": expected a number from 1 to 10000`},
+		{"GET", "/walk?start=2020-01-01&end=2020-02-01&after=2020-01-05T12:00:00Z", 400, `invalid after "2020-01-05T12:00:00Z": not a Day boundary of the walk`},
+		{"GET", "/round?start=2020-01-01", 400, "missing parameter end"},
+		{"POST", "/walk?start=2020-01-01&end=2020-02-01", 405, "method POST not allowed"},
+	}
+	for _, tt := range testData {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.target, nil))
+		var res struct{ Error string }
+		json.Unmarshal(rec.Body.Bytes(), &res)
+		if rec.Code != tt.status || res.Error != tt.exp {
+			t.Errorf("%s %s: \nexp: %d %s, \nact: %d %s", tt.method, tt.target, tt.status, tt.exp, rec.Code, res.Error)
+		}
+	}
+}
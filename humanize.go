@@ -0,0 +1,234 @@
+package timewalker
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Locale holds the names and patterns used by Interval.Humanize. Patterns are text with placeholders for the first step of an interval:
+// {a} short weekday, {A} weekday, {d} day, {b} short month, {B} month, {q} quarter, {Y} year,
+// and for the last step, suffixed with 2, e.g. {d2}. Locales are plain tables, so new ones need no download.
+type Locale struct {
+	Months        [12]string // January first
+	ShortMonths   [12]string
+	Weekdays      [7]string // Sunday first, as time.Weekday
+	ShortWeekdays [7]string
+
+	// Day labels a single day; days and weeks are ranges within a month, within a year, or across years
+	Day               string
+	DayRangeSameMonth string
+	DayRangeSameYear  string
+	DayRange          string
+
+	Month              string
+	MonthRangeSameYear string
+	MonthRange         string
+
+	Quarter              string
+	QuarterRangeSameYear string
+	QuarterRange         string
+
+	Year      string
+	YearRange string
+}
+
+// The built-in Locales
+var (
+	English = &Locale{
+		Months:        [12]string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"},
+		ShortMonths:   [12]string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"},
+		Weekdays:      [7]string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+		ShortWeekdays: [7]string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"},
+
+		Day:               "{a}, {b} {d}, {Y}",
+		DayRangeSameMonth: "{b} {d}–{d2}, {Y}",
+		DayRangeSameYear:  "{b} {d} – {b2} {d2}, {Y}",
+		DayRange:          "{b} {d}, {Y} – {b2} {d2}, {Y2}",
+
+		Month:              "{B} {Y}",
+		MonthRangeSameYear: "{b}–{b2} {Y}",
+		MonthRange:         "{b} {Y} – {b2} {Y2}",
+
+		Quarter:              "Q{q} {Y}",
+		QuarterRangeSameYear: "Q{q}–Q{q2} {Y}",
+		QuarterRange:         "Q{q} {Y} – Q{q2} {Y2}",
+
+		Year:      "{Y}",
+		YearRange: "{Y}–{Y2}",
+	}
+	French = &Locale{
+		Months:        [12]string{"janvier", "février", "mars", "avril", "mai", "juin", "juillet", "août", "septembre", "octobre", "novembre", "décembre"},
+		ShortMonths:   [12]string{"janv.", "févr.", "mars", "avr.", "mai", "juin", "juil.", "août", "sept.", "oct.", "nov.", "déc."},
+		Weekdays:      [7]string{"dimanche", "lundi", "mardi", "mercredi", "jeudi", "vendredi", "samedi"},
+		ShortWeekdays: [7]string{"dim.", "lun.", "mar.", "mer.", "jeu.", "ven.", "sam."},
+
+		Day:               "{a} {d} {b} {Y}",
+		DayRangeSameMonth: "{d}–{d2} {b} {Y}",
+		DayRangeSameYear:  "{d} {b} – {d2} {b2} {Y}",
+		DayRange:          "{d} {b} {Y} – {d2} {b2} {Y2}",
+
+		Month:              "{B} {Y}",
+		MonthRangeSameYear: "{b}–{b2} {Y}",
+		MonthRange:         "{b} {Y} – {b2} {Y2}",
+
+		Quarter:              "T{q} {Y}",
+		QuarterRangeSameYear: "T{q}–T{q2} {Y}",
+		QuarterRange:         "T{q} {Y} – T{q2} {Y2}",
+
+		Year:      "{Y}",
+		YearRange: "{Y}–{Y2}",
+	}
+	Spanish = &Locale{
+		Months:        [12]string{"enero", "febrero", "marzo", "abril", "mayo", "junio", "julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre"},
+		ShortMonths:   [12]string{"ene", "feb", "mar", "abr", "may", "jun", "jul", "ago", "sept", "oct", "nov", "dic"},
+		Weekdays:      [7]string{"domingo", "lunes", "martes", "miércoles", "jueves", "viernes", "sábado"},
+		ShortWeekdays: [7]string{"dom", "lun", "mar", "mié", "jue", "vie", "sáb"},
+
+		Day:               "{a}, {d} {b} {Y}",
+		DayRangeSameMonth: "{d}–{d2} {b} {Y}",
+		DayRangeSameYear:  "{d} {b} – {d2} {b2} {Y}",
+		DayRange:          "{d} {b} {Y} – {d2} {b2} {Y2}",
+
+		Month:              "{B} de {Y}",
+		MonthRangeSameYear: "{b}–{b2} {Y}",
+		MonthRange:         "{b} {Y} – {b2} {Y2}",
+
+		Quarter:              "T{q} {Y}",
+		QuarterRangeSameYear: "T{q}–T{q2} {Y}",
+		QuarterRange:         "T{q} {Y} – T{q2} {Y2}",
+
+		Year:      "{Y}",
+		YearRange: "{Y}–{Y2}",
+	}
+	German = &Locale{
+		Months:        [12]string{"Januar", "Februar", "März", "April", "Mai", "Juni", "Juli", "August", "September", "Oktober", "November", "Dezember"},
+		ShortMonths:   [12]string{"Jan.", "Feb.", "März", "Apr.", "Mai", "Juni", "Juli", "Aug.", "Sept.", "Okt.", "Nov.", "Dez."},
+		Weekdays:      [7]string{"Sonntag", "Montag", "Dienstag", "Mittwoch", "Donnerstag", "Freitag", "Samstag"},
+		ShortWeekdays: [7]string{"So.", "Mo.", "Di.", "Mi.", "Do.", "Fr.", "Sa."},
+
+		Day:               "{a}, {d}. {b} {Y}",
+		DayRangeSameMonth: "{d}.–{d2}. {b} {Y}",
+		DayRangeSameYear:  "{d}. {b} – {d2}. {b2} {Y}",
+		DayRange:          "{d}. {b} {Y} – {d2}. {b2} {Y2}",
+
+		Month:              "{B} {Y}",
+		MonthRangeSameYear: "{b}–{b2} {Y}",
+		MonthRange:         "{b} {Y} – {b2} {Y2}",
+
+		Quarter:              "Q{q} {Y}",
+		QuarterRangeSameYear: "Q{q}–Q{q2} {Y}",
+		QuarterRange:         "Q{q} {Y} – Q{q2} {Y2}",
+
+		Year:      "{Y}",
+		YearRange: "{Y}–{Y2}",
+	}
+)
+
+// Locales are the Locales by language tag; more can be added
+var Locales = map[string]*Locale{
+	"en": English,
+	"fr": French,
+	"es": Spanish,
+	"de": German,
+}
+
+// openEnded stands for an unbounded side in humanized intervals
+const openEnded = "…"
+
+// Humanize renders the receiver's interval concisely, at the granularity of d, collapsing the parts shared by its first and last steps,
+// e.g. Mar 3–9, 2024 for a Week, March 2024 for a Month, Jan–Mar 2024 for three Months, or Q1 2024 for a Quarter.
+// A Week is rendered as the range of its days. The interval is first rounded to d, as with Label; an unbounded side is rendered …,
+// and an invalid Duration renders the interval as String does. A nil Locale is English.
+func (i Interval) Humanize(d Duration, l *Locale) string {
+	if l == nil {
+		l = English
+	}
+	if _, err := d.MarshalText(); err != nil {
+		return i.String()
+	}
+	i = i.HalfOpen(d)
+	var first, last time.Time
+	if i.Bounds&StartUnbounded == 0 {
+		first = d.Floor(i.Start)
+	}
+	if i.Bounds&EndUnbounded == 0 {
+		end := d.Ceil(i.End)
+		if i.Bounds&StartUnbounded == 0 && !end.After(first) {
+			end = d.AddTo(first)
+		}
+		last, _ = d.AddN(end, -1, OverflowNormalize)
+		if d == Week {
+			last = end.AddDate(0, 0, -1)
+		}
+	}
+	switch {
+	case i.Bounds&StartUnbounded != 0 && i.Bounds&EndUnbounded != 0:
+		return openEnded
+	case i.Bounds&StartUnbounded != 0:
+		return openEnded + " – " + l.single(d, last)
+	case i.Bounds&EndUnbounded != 0:
+		return l.single(d, first) + " – " + openEnded
+	}
+
+	sameYear := first.Year() == last.Year()
+	var pattern string
+	switch d {
+	case Day, Week:
+		switch {
+		case d == Day && DateOf(first) == DateOf(last):
+			pattern = l.Day
+		case sameYear && first.Month() == last.Month():
+			pattern = l.DayRangeSameMonth
+		case sameYear:
+			pattern = l.DayRangeSameYear
+		default:
+			pattern = l.DayRange
+		}
+	case Month:
+		pattern = l.pick(first, last, l.Month, l.MonthRangeSameYear, l.MonthRange)
+	case Quarter:
+		pattern = l.pick(first, last, l.Quarter, l.QuarterRangeSameYear, l.QuarterRange)
+	case Year:
+		pattern = l.pick(first, last, l.Year, l.YearRange, l.YearRange)
+	}
+	return l.expand(pattern, first, last)
+}
+
+// pick chooses the pattern of a single step, of a range within a year, or of a range across years
+func (l *Locale) pick(first, last time.Time, single, sameYear, other string) string {
+	switch {
+	case first.Equal(last):
+		return single
+	case first.Year() == last.Year():
+		return sameYear
+	}
+	return other
+}
+
+// single renders the step of d starting at t, for the bounded side of a half-unbounded interval
+func (l *Locale) single(d Duration, t time.Time) string {
+	pattern := map[Duration]string{Day: l.Day, Week: l.Day, Month: l.Month, Quarter: l.Quarter, Year: l.Year}[d]
+	return l.expand(pattern, t, t)
+}
+
+// expand replaces the placeholders of pattern with the fields of first and last
+func (l *Locale) expand(pattern string, first, last time.Time) string {
+	var args []string
+	for _, side := range []struct {
+		suffix string
+		t      time.Time
+	}{{"2", last}, {"", first}} {
+		t := side.t
+		args = append(args,
+			"{a"+side.suffix+"}", l.ShortWeekdays[t.Weekday()],
+			"{A"+side.suffix+"}", l.Weekdays[t.Weekday()],
+			"{d"+side.suffix+"}", strconv.Itoa(t.Day()),
+			"{b"+side.suffix+"}", l.ShortMonths[t.Month()-1],
+			"{B"+side.suffix+"}", l.Months[t.Month()-1],
+			"{q"+side.suffix+"}", strconv.Itoa((int(t.Month())-1)/3+1),
+			"{Y"+side.suffix+"}", strconv.Itoa(t.Year()),
+		)
+	}
+	return strings.NewReplacer(args...).Replace(pattern)
+}
@@ -0,0 +1,83 @@
+package timewalker
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestIntervalHumanize(t *testing.T) {
+	var testData = []struct {
+		i   Interval // interval
+		d   Duration // granularity
+		l   *Locale  // Locale
+		exp string   // expected rendering
+	}{
+		{parseIntvl("2024-03-07T00:00:00Z", "2024-03-08T00:00:00Z"), Day, nil, "Thu, Mar 7, 2024"},
+		{parseIntvl("2024-03-03T00:00:00Z", "2024-03-10T00:00:00Z"), Day, English, "Mar 3–9, 2024"},
+		{parseIntvl("2024-03-28T00:00:00Z", "2024-04-04T00:00:00Z"), Day, English, "Mar 28 – Apr 3, 2024"},
+		{parseIntvl("2024-12-30T00:00:00Z", "2025-01-06T00:00:00Z"), Week, English, "Dec 30, 2024 – Jan 5, 2025"},
+		{parseIntvl("2024-03-06T12:00:00Z", "2024-03-07T00:00:00Z"), Week, English, "Mar 4–10, 2024"},
+		{parseIntvl("2024-03-01T00:00:00Z", "2024-04-01T00:00:00Z"), Month, English, "March 2024"},
+		{parseIntvl("2024-01-01T00:00:00Z", "2024-04-01T00:00:00Z"), Month, English, "Jan–Mar 2024"},
+		{parseIntvl("2023-11-01T00:00:00Z", "2024-03-01T00:00:00Z"), Month, English, "Nov 2023 – Feb 2024"},
+		{parseIntvl("2024-01-01T00:00:00Z", "2024-04-01T00:00:00Z"), Quarter, English, "Q1 2024"},
+		{parseIntvl("2024-01-01T00:00:00Z", "2024-10-01T00:00:00Z"), Quarter, English, "Q1–Q3 2024"},
+		{parseIntvl("2023-10-01T00:00:00Z", "2024-04-01T00:00:00Z"), Quarter, English, "Q4 2023 – Q1 2024"},
+		{parseIntvl("2024-01-01T00:00:00Z", "2025-01-01T00:00:00Z"), Year, English, "2024"},
+		{parseIntvl("2022-01-01T00:00:00Z", "2025-01-01T00:00:00Z"), Year, English, "2022–2024"},
+		{Interval{Start: parseTime("2024-03-01T00:00:00Z"), End: parseTime("2024-03-31T00:00:00Z"), Bounds: Closed}, Day, English, "Mar 1–31, 2024"},
+		{Interval{Start: parseTime("2024-03-01T00:00:00Z"), Bounds: EndUnbounded}, Month, English, "March 2024 – …"},
+		{Interval{End: parseTime("2024-03-01T00:00:00Z"), Bounds: StartUnbounded}, Day, English, "… – Thu, Feb 29, 2024"},
+		{Interval{Bounds: StartUnbounded | EndUnbounded}, Day, English, "…"},
+		{parseIntvl("2024-03-07T00:00:00Z", "2024-03-08T00:00:00Z"), Duration(42), English, "[2024-03-07T00:00:00Z, 2024-03-08T00:00:00Z)"},
+
+		{parseIntvl("2024-03-07T00:00:00Z", "2024-03-08T00:00:00Z"), Day, French, "jeu. 7 mars 2024"},
+		{parseIntvl("2024-03-03T00:00:00Z", "2024-03-10T00:00:00Z"), Day, French, "3–9 mars 2024"},
+		{parseIntvl("2024-01-29T00:00:00Z", "2024-02-05T00:00:00Z"), Week, French, "29 janv. – 4 févr. 2024"},
+		{parseIntvl("2024-07-01T00:00:00Z", "2024-10-01T00:00:00Z"), Quarter, French, "T3 2024"},
+		{parseIntvl("2024-03-07T00:00:00Z", "2024-03-08T00:00:00Z"), Day, Spanish, "jue, 7 mar 2024"},
+		{parseIntvl("2024-03-01T00:00:00Z", "2024-04-01T00:00:00Z"), Month, Spanish, "marzo de 2024"},
+		{parseIntvl("2024-03-07T00:00:00Z", "2024-03-08T00:00:00Z"), Day, German, "Do., 7. März 2024"},
+		{parseIntvl("2024-03-03T00:00:00Z", "2024-03-10T00:00:00Z"), Day, German, "3.–9. März 2024"},
+		{parseIntvl("2023-12-01T00:00:00Z", "2024-02-01T00:00:00Z"), Month, German, "Dez. 2023 – Jan. 2024"},
+	}
+	for _, tt := range testData {
+		if actual := tt.i.Humanize(tt.d, tt.l); actual != tt.exp {
+			t.Errorf("%v.Humanize(%v): exp: %v act: %v", tt.i, tt.d, tt.exp, actual)
+		}
+	}
+}
+
+func TestLocales(t *testing.T) {
+	for tag, l := range Locales {
+		for k := range l.Months {
+			if l.Months[k] == "" || l.ShortMonths[k] == "" {
+				t.Errorf("Locale %s: missing name of month %d", tag, k+1)
+			}
+		}
+		for k := range l.Weekdays {
+			if l.Weekdays[k] == "" || l.ShortWeekdays[k] == "" {
+				t.Errorf("Locale %s: missing name of weekday %d", tag, k)
+			}
+		}
+	}
+}
+
+// Chart headers for the weeks of a month, in French
+func ExampleInterval_Humanize() {
+	i := Interval{
+		Start: time.Date(2024, time.February, 26, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2024, time.March, 18, 0, 0, 0, 0, time.UTC),
+	}
+	weeks, _ := i.Walk(Week)
+	for week := range weeks {
+		fmt.Println(week.Humanize(Week, Locales["fr"]))
+	}
+	fmt.Println(i.Humanize(Month, Locales["fr"]))
+	// Output:
+	// 26 févr. – 3 mars 2024
+	// 4–10 mars 2024
+	// 11–17 mars 2024
+	// févr.–mars 2024
+}
@@ -0,0 +1,262 @@
+package timewalker
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RepeatingInterval represents an ISO 8601 repeating interval, e.g. R5/2008-03-01T13:00:00Z/P1Y2M10DT2H30M
+type RepeatingInterval struct {
+	// Repetitions is the number of intervals, or -1 when unbounded (R/...)
+	Repetitions int
+	// Start is the first occurrence
+	Start time.Time
+	// Period separates consecutive occurrences, and is the length of each occurrence
+	Period Period
+}
+
+// ISO8601 formats the receiver as an ISO 8601 interval: start/end, both in RFC 3339 format.
+// An unbounded Start or End is written with the open bound notation of ISO 8601-2, e.g. 2001-01-01T00:00:00Z/..
+// ISO 8601 intervals are half-open, so an exclusive Start or inclusive End is an error, as for MarshalText: normalize it with HalfOpen first.
+func (i Interval) ISO8601() (string, error) {
+	if err := i.checkHalfOpen(); err != nil {
+		return "", err
+	}
+	return i.format(func(t time.Time) string { return t.Format(time.RFC3339Nano) }), nil
+}
+
+// checkHalfOpen returns an error unless the receiver is half-open, as ISO 8601 intervals are
+func (i Interval) checkHalfOpen() error {
+	if i.Bounds&(StartExclusive|EndInclusive) != 0 {
+		return fmt.Errorf("cannot format %v: ISO 8601 intervals are half-open", i)
+	}
+	return nil
+}
+
+// format formats the receiver as start/end using the given time formatter, writing unbounded boundaries as ..
+func (i Interval) format(formatTime func(time.Time) string) string {
+	start, end := openBound, openBound
+	if i.Bounds&StartUnbounded == 0 {
+		start = formatTime(i.Start)
+	}
+	if i.Bounds&EndUnbounded == 0 {
+		end = formatTime(i.End)
+	}
+	return start + "/" + end
+}
+
+// openBound is the ISO 8601-2 notation for an unbounded start or end
+const openBound = ".."
+
+// ParseInterval parses an ISO 8601 interval in any of the start/end, start/duration or duration/end forms, e.g.
+// 2007-03-01T13:00:00Z/2008-05-11T15:30:00Z, 2007-03-01T13:00:00Z/P1Y2M10DT2H30M or P1Y2M10DT2H30M/2008-05-11T15:30:00Z.
+// Times are in RFC 3339 format, optionally suffixed with a zone name as produced by MarshalText; the duration is added to the start, or subtracted from the end, as Period.AddTo does.
+// A time may also be a calendar date, e.g. 2007-03-01/2007-03-05, which stands for the start of that day in UTC, or in the zone of its suffix, e.g. 2007-03-01[America/Montreal].
+// Other reduced precision forms, such as a year and month, or an end omitting the leading components of the start (2007-03-01T13:00:00Z/15:30:00Z), are not accepted.
+// An unbounded start or end is written .. as in ISO 8601-2, e.g. ../2008-05-11T15:30:00Z, and cannot be combined with a duration.
+// Durations are not negative in ISO 8601, so a negative duration, or duration component, is an error.
+func ParseInterval(s string) (Interval, error) {
+	parts := splitISO(s)
+	if len(parts) != 2 {
+		return Interval{}, fmt.Errorf("invalid ISO 8601 interval %q: expected two parts separated by '/'", s)
+	}
+	if parts[0] == openBound || parts[1] == openBound {
+		return parseOpenInterval(s, parts)
+	}
+	if isPeriod(parts[0]) && isPeriod(parts[1]) {
+		return Interval{}, fmt.Errorf("invalid ISO 8601 interval %q: duration/duration is not an interval", s)
+	}
+
+	var i Interval
+	switch {
+	case isPeriod(parts[0]):
+		p, err := ParsePeriod(parts[0])
+		if err != nil {
+			return Interval{}, err
+		}
+		if p.hasNegative() {
+			return Interval{}, fmt.Errorf("invalid ISO 8601 interval %q: negative duration %v", s, p)
+		}
+		if i.End, err = parseISOTime(parts[1]); err != nil {
+			return Interval{}, err
+		}
+		i.Start = p.Neg().AddTo(i.End)
+	case isPeriod(parts[1]):
+		p, err := ParsePeriod(parts[1])
+		if err != nil {
+			return Interval{}, err
+		}
+		if p.hasNegative() {
+			return Interval{}, fmt.Errorf("invalid ISO 8601 interval %q: negative duration %v", s, p)
+		}
+		if i.Start, err = parseISOTime(parts[0]); err != nil {
+			return Interval{}, err
+		}
+		i.End = p.AddTo(i.Start)
+	default:
+		var err error
+		if i.Start, err = parseISOTime(parts[0]); err != nil {
+			return Interval{}, err
+		}
+		if i.End, err = parseISOTime(parts[1]); err != nil {
+			return Interval{}, err
+		}
+	}
+	return i, nil
+}
+
+// parseOpenInterval parses the start/end parts of an ISO 8601 interval, when at least one of them is unbounded
+func parseOpenInterval(s string, parts []string) (Interval, error) {
+	var i Interval
+	var err error
+	if isPeriod(parts[0]) || isPeriod(parts[1]) {
+		return Interval{}, fmt.Errorf("invalid ISO 8601 interval %q: an unbounded interval cannot have a duration", s)
+	}
+	if parts[0] == openBound {
+		i.Bounds |= StartUnbounded
+	} else if i.Start, err = parseISOTime(parts[0]); err != nil {
+		return Interval{}, err
+	}
+	if parts[1] == openBound {
+		i.Bounds |= EndUnbounded
+	} else if i.End, err = parseISOTime(parts[1]); err != nil {
+		return Interval{}, err
+	}
+	return i, nil
+}
+
+// String formats the receiver as an ISO 8601 repeating interval: Rn/start/duration, with the start zone name as in Interval.MarshalText
+func (r RepeatingInterval) String() string {
+	n := ""
+	if r.Repetitions >= 0 {
+		n = strconv.Itoa(r.Repetitions)
+	}
+	return "R" + n + "/" + formatISOTime(r.Start) + "/" + r.Period.String()
+}
+
+// ParseRepeatingInterval parses an ISO 8601 repeating interval: Rn/interval, where the interval is in any of the forms accepted by ParseInterval,
+// e.g. R5/2008-03-01T13:00:00Z/P1Y2M10DT2H30M. Without n (R/...), the number of repetitions is unbounded.
+// For the start/end form, the period is the exact elapsed time between start and end,
+// and for the duration/end form, the last of the n occurrences ends at end: Start is end - n*period, and occurrences are walked from Start.
+// With month arithmetic, occurrences are then irregular, as for the start/duration form, e.g. R3/P1M/2024-03-31T00:00:00Z
+// starts on Dec 31 and its second occurrence is [Jan 31, Mar 2). When no such Start exists, e.g. for R1/P1M/2024-03-31T00:00:00Z,
+// as Mar 31 - 1 month is normalized to Mar 2, the repeating interval is an error.
+func ParseRepeatingInterval(s string) (RepeatingInterval, error) {
+	if !strings.HasPrefix(s, "R") {
+		return RepeatingInterval{}, fmt.Errorf("invalid ISO 8601 repeating interval %q: expected 'R'", s)
+	}
+	slash := strings.IndexByte(s, '/')
+	if slash < 0 {
+		return RepeatingInterval{}, fmt.Errorf("invalid ISO 8601 repeating interval %q: expected '/'", s)
+	}
+	r := RepeatingInterval{Repetitions: -1}
+	if n := s[1:slash]; n != "" {
+		var err error
+		r.Repetitions, err = strconv.Atoi(n)
+		if err != nil || r.Repetitions < 0 {
+			return RepeatingInterval{}, fmt.Errorf("invalid ISO 8601 repeating interval %q: invalid number of repetitions %q", s, n)
+		}
+	}
+
+	rest := s[slash+1:]
+	parts := splitISO(rest)
+	if len(parts) != 2 {
+		return RepeatingInterval{}, fmt.Errorf("invalid ISO 8601 repeating interval %q: expected two parts after repetitions", s)
+	}
+	i, err := ParseInterval(rest)
+	if err != nil {
+		return RepeatingInterval{}, err
+	}
+	r.Start = i.Start
+	switch {
+	case isPeriod(parts[1]):
+		r.Period, _ = ParsePeriod(parts[1])
+	case isPeriod(parts[0]):
+		// the last occurrence ends at the given end
+		if r.Repetitions < 0 {
+			return RepeatingInterval{}, fmt.Errorf("invalid ISO 8601 repeating interval %q: duration/end requires a number of repetitions", s)
+		}
+		r.Period, _ = ParsePeriod(parts[0])
+		r.Start = r.Period.times(-r.Repetitions).AddTo(i.End)
+		if !r.Period.times(r.Repetitions).AddTo(r.Start).Equal(i.End) {
+			return RepeatingInterval{}, fmt.Errorf("invalid ISO 8601 repeating interval %q: no %d occurrences of %v end at %s", s, r.Repetitions, r.Period, parts[1])
+		}
+	default:
+		r.Period = periodOf(i.End.Sub(i.Start))
+	}
+	if !r.Period.AddTo(r.Start).After(r.Start) {
+		return RepeatingInterval{}, fmt.Errorf("invalid ISO 8601 repeating interval %q: period must be positive", s)
+	}
+	return r, nil
+}
+
+// Walk produces the receiver's successive occurrences, the k-th one starting at Start + k*Period.
+// When the number of repetitions is unbounded, the occurrences must be walked with WalkContext, so that the walk can be stopped.
+func (r RepeatingInterval) Walk() (<-chan Interval, error) {
+	if r.Repetitions < 0 {
+		return nil, fmt.Errorf("cannot walk unbounded repetitions without a Context, see WalkContext: %v", r)
+	}
+	return r.WalkContext(context.Background())
+}
+
+// WalkContext is like Walk, but the walk stops, and the channel is closed, when ctx is done.
+// When the number of repetitions is unbounded, the walk only stops then, so the consumer decides when to stop.
+func (r RepeatingInterval) WalkContext(ctx context.Context) (<-chan Interval, error) {
+	if err := validateWalkPeriod(r.Start, r.Period); err != nil {
+		return nil, err
+	}
+	ch := make(chan Interval)
+	go func() {
+		defer close(ch)
+		start := r.Start
+		for k := 1; r.Repetitions < 0 || k <= r.Repetitions; k++ {
+			end := r.Period.times(k).AddTo(r.Start)
+			if !sendInterval(ctx, ch, Interval{Start: start, End: end}) {
+				return
+			}
+			start = end
+		}
+	}()
+	return ch, nil
+}
+
+// periodOf returns the Period with the exact elapsed time d, in hours, minutes, seconds and nanoseconds
+func periodOf(d time.Duration) Period {
+	var p Period
+	p.Hours = int(d / time.Hour)
+	d -= time.Duration(p.Hours) * time.Hour
+	p.Minutes = int(d / time.Minute)
+	d -= time.Duration(p.Minutes) * time.Minute
+	p.Seconds = int(d / time.Second)
+	d -= time.Duration(p.Seconds) * time.Second
+	p.Nanoseconds = int(d)
+	return p
+}
+
+// isPeriod reports whether an interval part is a duration rather than a time
+func isPeriod(s string) bool {
+	return strings.HasPrefix(s, "P") || strings.HasPrefix(s, "-P")
+}
+
+// splitISO splits an ISO 8601 interval on '/', ignoring the slashes within zone names, e.g. [America/Montreal]
+func splitISO(s string) []string {
+	var parts []string
+	depth, start := 0, 0
+	for pos, c := range s {
+		switch c {
+		case '[':
+			depth++
+		case ']':
+			depth--
+		case '/':
+			if depth == 0 {
+				parts = append(parts, s[start:pos])
+				start = pos + 1
+			}
+		}
+	}
+	return append(parts, s[start:])
+}
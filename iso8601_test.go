@@ -0,0 +1,230 @@
+package timewalker
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseInterval(t *testing.T) {
+	var testData = []struct {
+		inp string   // input
+		exp Interval // expected result
+	}{
+		{ // start/end
+			inp: "2007-03-01T13:00:00Z/2008-05-11T15:30:00Z",
+			exp: parseIntvl("2007-03-01T13:00:00Z", "2008-05-11T15:30:00Z"),
+		}, { // start/duration
+			inp: "2007-03-01T13:00:00Z/P1Y2M10DT2H30M",
+			exp: parseIntvl("2007-03-01T13:00:00Z", "2008-05-11T15:30:00Z"),
+		}, { // duration/end
+			inp: "P1Y2M10DT2H30M/2008-05-11T15:30:00Z",
+			exp: parseIntvl("2007-03-01T13:00:00Z", "2008-05-11T15:30:00Z"),
+		}, { // unbounded start
+			inp: "../2008-05-11T15:30:00Z",
+			exp: Interval{End: parseTime("2008-05-11T15:30:00Z"), Bounds: StartUnbounded},
+		}, { // unbounded end
+			inp: "2007-03-01T13:00:00Z/..",
+			exp: Interval{Start: parseTime("2007-03-01T13:00:00Z"), Bounds: EndUnbounded},
+		}, { // unbounded
+			inp: "../..",
+			exp: Interval{Bounds: StartUnbounded | EndUnbounded},
+		}, { // fractional seconds
+			inp: "2007-03-01T13:00:00.5Z/PT0.5S",
+			exp: parseIntvl("2007-03-01T13:00:00.5Z", "2007-03-01T13:00:01Z"),
+		}, { // calendar dates, in UTC
+			inp: "2007-03-01/2007-03-05",
+			exp: parseIntvl("2007-03-01T00:00:00Z", "2007-03-05T00:00:00Z"),
+		}, { // calendar date and duration
+			inp: "2007-03-01/P1D",
+			exp: parseIntvl("2007-03-01T00:00:00Z", "2007-03-02T00:00:00Z"),
+		}, { // calendar dates in a zone, across a daylight savings transition
+			inp: "2007-03-11[America/Montreal]/2007-03-12[America/Montreal]",
+			exp: parseIntvl("2007-03-11T05:00:00Z", "2007-03-12T04:00:00Z"),
+		}, { // calendar date with an unbounded end
+			inp: "2007-03-01/..",
+			exp: Interval{Start: parseTime("2007-03-01T00:00:00Z"), Bounds: EndUnbounded},
+		},
+	}
+	for _, tt := range testData {
+		actual, err := ParseInterval(tt.inp)
+		if err != nil {
+			t.Errorf("ParseInterval(%q): unexpected error: %v", tt.inp, err)
+			continue
+		}
+		if !actual.Start.Equal(tt.exp.Start) || !actual.End.Equal(tt.exp.End) || actual.Bounds != tt.exp.Bounds {
+			t.Errorf("ParseInterval(%q): \nexp: %v, \nact: %v", tt.inp, tt.exp, actual)
+		}
+		// round trip
+		text, err := actual.ISO8601()
+		if err != nil {
+			t.Errorf("ParseInterval(%q).ISO8601(): unexpected error: %v", tt.inp, err)
+			continue
+		}
+		rt, err := ParseInterval(text)
+		if err != nil || !rt.Start.Equal(actual.Start) || !rt.End.Equal(actual.End) || rt.Bounds != actual.Bounds {
+			t.Errorf("ParseInterval(%q): round trip: %v, %v", text, rt, err)
+		}
+	}
+}
+
+func TestParseIntervalErrors(t *testing.T) {
+	var testData = []struct {
+		inp string // input
+		exp string // expected error prefix
+	}{
+		{"2007-03-01T13:00:00Z", `invalid ISO 8601 interval "2007-03-01T13:00:00Z": expected two parts separated by '/'`},
+		{"P1D/P2D", `invalid ISO 8601 interval "P1D/P2D": duration/duration is not an interval`},
+		{"../P1D", `invalid ISO 8601 interval "../P1D": an unbounded interval cannot have a duration`},
+		{"../2007-03", `invalid ISO 8601 interval time "2007-03": parsing time`},
+		{"2007-03-01T13:00:00Z/15:30:00Z", `invalid ISO 8601 interval time "15:30:00Z": parsing time`},
+		{"2007-03-01[Mars/Olympus_Mons]/P1D", `invalid ISO 8601 interval time "2007-03-01": unknown time zone Mars/Olympus_Mons`},
+		{"2007-03-01T13:00:00Z/P1X", `invalid ISO 8601 duration "P1X": unexpected character 'X' at position 2`},
+		{"2007-02-30/P1D", `invalid ISO 8601 interval time "2007-02-30": parsing time`},
+		{"2024-01-01T00:00:00Z/-P1D", `invalid ISO 8601 interval "2024-01-01T00:00:00Z/-P1D": negative duration -P1D`},
+		{"-P1D/2024-01-01T00:00:00Z", `invalid ISO 8601 interval "-P1D/2024-01-01T00:00:00Z": negative duration -P1D`},
+		{"2024-01-01T00:00:00Z/P1M-1D", `invalid ISO 8601 interval "2024-01-01T00:00:00Z/P1M-1D": negative duration P1M-1D`},
+	}
+	for _, tt := range testData {
+		_, err := ParseInterval(tt.inp)
+		if err == nil || !strings.HasPrefix(err.Error(), tt.exp) {
+			t.Errorf("ParseInterval(%q): \nexp: %v, \nact: %v", tt.inp, tt.exp, err)
+		}
+	}
+}
+
+func TestISO8601Bounds(t *testing.T) {
+	// ISO8601 and MarshalText reject the same Bounds
+	for _, b := range []Bounds{StartExclusive, EndInclusive, Open | Closed} {
+		i := Interval{Start: parseTime("2007-03-01T00:00:00Z"), End: parseTime("2007-03-05T00:00:00Z"), Bounds: b}
+		_, err := i.ISO8601()
+		_, merr := i.MarshalText()
+		if err == nil || merr == nil || err.Error() != merr.Error() {
+			t.Errorf("%v.ISO8601(): expected the error of MarshalText, got %v and %v", i, err, merr)
+		}
+		if text, err := i.HalfOpen(Day).ISO8601(); err != nil || text == "" {
+			t.Errorf("%v.HalfOpen(Day).ISO8601(): unexpected %q, %v", i, text, err)
+		}
+	}
+}
+
+func TestParseIntervalHostZone(t *testing.T) {
+	// the same text parses to the same Interval, whatever the host's zone, e.g. TZ=UTC or TZ=America/Montreal
+	defer func(local *time.Location) { time.Local = local }(time.Local)
+	montreal, _ := time.LoadLocation("America/Montreal")
+	const inp = "2001-01-01T00:00:00-05:00/2001-01-02T00:00:00Z"
+	for _, local := range []*time.Location{time.UTC, montreal} {
+		time.Local = local
+		i, err := ParseInterval(inp)
+		if err != nil {
+			t.Fatalf("ParseInterval(%q) with Local %v: unexpected error: %v", inp, local, err)
+		}
+		name, offset := i.Start.Zone()
+		if i.Start.Location() == time.Local || name != "" || offset != -5*3600 || i.End.Location() != time.UTC {
+			t.Errorf("ParseInterval(%q) with Local %v: unexpected Locations %v and %v", inp, local, i.Start.Location(), i.End.Location())
+		}
+		if text, _ := i.MarshalText(); string(text) != inp {
+			t.Errorf("ParseInterval(%q).MarshalText() with Local %v: %s", inp, local, text)
+		}
+	}
+}
+
+func TestParseRepeatingInterval(t *testing.T) {
+	var testData = []struct {
+		inp string // input
+		exp string // expected String()
+	}{
+		{"R5/2008-03-01T13:00:00Z/P1Y2M10DT2H30M", "R5/2008-03-01T13:00:00Z/P1Y2M10DT2H30M"},
+		{"R/2008-03-01T13:00:00Z/P1D", "R/2008-03-01T13:00:00Z/P1D"},
+		{"R0/2008-03-01T13:00:00Z/P1D", "R0/2008-03-01T13:00:00Z/P1D"},
+		{"R2/2008-03-01T13:00:00Z/2008-03-02T14:30:00.5Z", "R2/2008-03-01T13:00:00Z/PT25H30M0.5S"},
+		{"R3/P1D/2008-03-04T00:00:00Z", "R3/2008-03-01T00:00:00Z/P1D"},
+		{"R3/P1M/2024-03-31T00:00:00Z", "R3/2023-12-31T00:00:00Z/P1M"},
+	}
+	for _, tt := range testData {
+		actual, err := ParseRepeatingInterval(tt.inp)
+		if err != nil {
+			t.Errorf("ParseRepeatingInterval(%q): unexpected error: %v", tt.inp, err)
+			continue
+		}
+		if actual.String() != tt.exp {
+			t.Errorf("ParseRepeatingInterval(%q): \nexp: %v, \nact: %v", tt.inp, tt.exp, actual)
+		}
+	}
+
+	for _, inp := range []string{
+		"2008-03-01T13:00:00Z/P1D",
+		"Rx/2008-03-01T13:00:00Z/P1D",
+		"R-1/2008-03-01T13:00:00Z/P1D",
+		"R5",
+		"R5/2008-03-01T13:00:00Z",
+		"R5/2008-03-01T13:00:00Z/PT0S",
+		"R/P1D/2008-03-01T13:00:00Z",
+		"R1/P1M/2024-03-31T00:00:00Z",
+		"R2/2008-03-01T13:00:00Z/-P1D",
+	} {
+		if _, err := ParseRepeatingInterval(inp); err == nil {
+			t.Errorf("ParseRepeatingInterval(%q): expected an error", inp)
+		}
+	}
+}
+
+func TestParseRepeatingIntervalFromEnd(t *testing.T) {
+	// the last occurrence ends at end, but month arithmetic makes the middle one irregular
+	r, err := ParseRepeatingInterval("R3/P1M/2024-03-31T00:00:00Z")
+	if err != nil {
+		t.Fatalf("ParseRepeatingInterval: unexpected error: %v", err)
+	}
+	ch, _ := r.Walk()
+	var act []string
+	for i := range ch {
+		act = append(act, i.String())
+	}
+	exp := []string{
+		"[2023-12-31T00:00:00Z, 2024-01-31T00:00:00Z)",
+		"[2024-01-31T00:00:00Z, 2024-03-02T00:00:00Z)",
+		"[2024-03-02T00:00:00Z, 2024-03-31T00:00:00Z)",
+	}
+	if strings.Join(act, " ") != strings.Join(exp, " ") {
+		t.Errorf("ParseRepeatingInterval(%v).Walk(): \nexp: %v, \nact: %v", r, exp, act)
+	}
+}
+
+func ExampleParseInterval() {
+	i, _ := ParseInterval("2001-01-31T00:00:00Z/P1M")
+	fmt.Println(i)
+	text, _ := i.ISO8601()
+	fmt.Println(text)
+	// Output:
+	// [2001-01-31T00:00:00Z, 2001-03-03T00:00:00Z)
+	// 2001-01-31T00:00:00Z/2001-03-03T00:00:00Z
+}
+
+func ExampleRepeatingInterval_Walk() {
+	r, _ := ParseRepeatingInterval("R3/2004-01-31T00:00:00Z/P1M")
+	ch, _ := r.Walk()
+	for i := range ch {
+		fmt.Println(i)
+	}
+
+	// unbounded: stop when we have seen enough, and cancel the walk
+	r, _ = ParseRepeatingInterval("R/2004-01-01T00:00:00Z/PT8H")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ch, _ = r.WalkContext(ctx)
+	for i := range ch {
+		if i.Start.Day() > 1 {
+			break
+		}
+		fmt.Println(i)
+	}
+	// Output:
+	// [2004-01-31T00:00:00Z, 2004-03-02T00:00:00Z)
+	// [2004-03-02T00:00:00Z, 2004-03-31T00:00:00Z)
+	// [2004-03-31T00:00:00Z, 2004-05-01T00:00:00Z)
+	// [2004-01-01T00:00:00Z, 2004-01-01T08:00:00Z)
+	// [2004-01-01T08:00:00Z, 2004-01-01T16:00:00Z)
+	// [2004-01-01T16:00:00Z, 2004-01-02T00:00:00Z)
+}
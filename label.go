@@ -0,0 +1,53 @@
+package timewalker
+
+import (
+	"fmt"
+	"time"
+)
+
+// labelFormats are the strftime formats of Interval labels, by Duration
+var labelFormats = map[Duration]string{
+	Day:   "%Y-%m-%d",
+	Week:  "%G-W%V",
+	Month: "%Y-%m",
+	Year:  "%Y",
+}
+
+// Label returns a compact label for the receiver's interval, at the granularity of d:
+// 2024 for a Year, 2024-Q1 for a Quarter, 2024-03 for a Month, 2024-W10 for an ISO 8601 Week, and 2024-03-07 for a Day.
+// An interval spanning several steps of d is labeled by its first and last steps, e.g. 2024-03/2024-05 for March to May,
+// with .. for an unbounded side. The interval is first rounded to d, see Interval.Round, each side in its own Location.
+// An invalid Duration labels the interval as String does.
+func (i Interval) Label(d Duration) string {
+	if _, ok := labelFormats[d]; !ok && d != Quarter {
+		return i.String()
+	}
+	i = i.HalfOpen(d)
+	first, last := openBound, openBound
+	var start, end time.Time
+	if i.Bounds&StartUnbounded == 0 {
+		start = d.Floor(i.Start)
+		first = d.label(start)
+	}
+	if i.Bounds&EndUnbounded == 0 {
+		end = d.Ceil(i.End)
+		if i.Bounds&StartUnbounded == 0 && !end.After(start) {
+			end = d.AddTo(start)
+		}
+		lastStart, _ := d.AddN(end, -1, OverflowNormalize)
+		last = d.label(lastStart)
+	}
+	if first == last {
+		return first
+	}
+	return first + "/" + last
+}
+
+// label formats the step of the receiver's Duration starting at t
+func (d Duration) label(t time.Time) string {
+	if d == Quarter {
+		return fmt.Sprintf("%04d-Q%d", t.Year(), (int(t.Month())-1)/3+1)
+	}
+	f, _ := parseStrftime(labelFormats[d])
+	return f.format(t)
+}
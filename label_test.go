@@ -0,0 +1,51 @@
+package timewalker
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestIntervalLabel(t *testing.T) {
+	var testData = []struct {
+		i   Interval // interval
+		d   Duration // granularity
+		exp string   // expected label
+	}{
+		{parseIntvl("2024-01-01T00:00:00Z", "2025-01-01T00:00:00Z"), Year, "2024"},
+		{parseIntvl("2024-01-01T00:00:00Z", "2024-04-01T00:00:00Z"), Quarter, "2024-Q1"},
+		{parseIntvl("2024-03-01T00:00:00Z", "2024-04-01T00:00:00Z"), Month, "2024-03"},
+		{parseIntvl("2024-03-04T00:00:00Z", "2024-03-11T00:00:00Z"), Week, "2024-W10"},
+		{parseIntvl("2024-03-07T00:00:00Z", "2024-03-08T00:00:00Z"), Day, "2024-03-07"},
+		{parseIntvl("2024-12-30T00:00:00Z", "2025-01-06T00:00:00Z"), Week, "2025-W01"},
+		{parseIntvl("2024-03-07T12:00:00Z", "2024-03-07T13:00:00Z"), Day, "2024-03-07"},
+		{parseIntvl("2024-03-01T00:00:00Z", "2024-06-01T00:00:00Z"), Month, "2024-03/2024-05"},
+		{parseIntvl("2024-02-15T00:00:00Z", "2024-08-15T00:00:00Z"), Quarter, "2024-Q1/2024-Q3"},
+		{Interval{Start: parseTime("2024-03-01T00:00:00Z"), End: parseTime("2024-03-31T00:00:00Z"), Bounds: Closed}, Day, "2024-03-01/2024-03-31"},
+		{Interval{Start: parseTime("2024-03-01T00:00:00Z"), Bounds: EndUnbounded}, Month, "2024-03/.."},
+		{Interval{End: parseTime("2024-03-01T00:00:00Z"), Bounds: StartUnbounded}, Year, "../2024"},
+		{Interval{End: parseTime("2024-01-01T00:00:00Z"), Bounds: StartUnbounded}, Year, "../2023"},
+		{parseIntvl("2024-03-01T00:00:00Z", "2024-04-01T00:00:00Z"), Duration(42), "[2024-03-01T00:00:00Z, 2024-04-01T00:00:00Z)"},
+	}
+	for _, tt := range testData {
+		if actual := tt.i.Label(tt.d); actual != tt.exp {
+			t.Errorf("%v.Label(%v): exp: %v act: %v", tt.i, tt.d, tt.exp, actual)
+		}
+	}
+}
+
+func ExampleInterval_Label() {
+	i := Interval{
+		Start: time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2024, time.July, 1, 0, 0, 0, 0, time.UTC),
+	}
+	quarters, _ := i.Walk(Quarter)
+	for q := range quarters {
+		fmt.Println(q.Label(Quarter))
+	}
+	fmt.Println(i.Label(Month))
+	// Output:
+	// 2024-Q1
+	// 2024-Q2
+	// 2024-01/2024-06
+}
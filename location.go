@@ -0,0 +1,61 @@
+package timewalker
+
+import (
+	"fmt"
+	"time"
+)
+
+// LocationPolicy decides in which Location an Interval is rounded and walked, typically when its Start and End are in different Locations.
+// RejectMixedLocations, StartLocation, EndLocation and ExplicitLocation are the usual policies.
+type LocationPolicy func(i Interval) (*time.Location, error)
+
+// RejectMixedLocations returns an error when Start and End are in different Locations, which is what Interval.Round does
+func RejectMixedLocations(i Interval) (*time.Location, error) {
+	start, _ := StartLocation(i)
+	end, _ := EndLocation(i)
+	if start != end {
+		return nil, fmt.Errorf("Interval boundaries have in different time.Location: %s!=%s, %v", start, end, i)
+	}
+	return start, nil
+}
+
+// StartLocation uses the Location of Start, or of End when Start is unbounded
+func StartLocation(i Interval) (*time.Location, error) {
+	if i.Bounds&StartUnbounded != 0 {
+		return i.End.Location(), nil
+	}
+	return i.Start.Location(), nil
+}
+
+// EndLocation uses the Location of End, or of Start when End is unbounded
+func EndLocation(i Interval) (*time.Location, error) {
+	if i.Bounds&EndUnbounded != 0 {
+		return i.Start.Location(), nil
+	}
+	return i.End.Location(), nil
+}
+
+// ExplicitLocation returns a policy which always uses loc, whatever the Locations of Start and End
+func ExplicitLocation(loc *time.Location) LocationPolicy {
+	return func(Interval) (*time.Location, error) {
+		return loc, nil
+	}
+}
+
+// RoundWith is like Round, but first moves Start and End to the Location chosen by the policy, instead of returning an error when they differ
+func (i Interval) RoundWith(d Duration, p LocationPolicy) (Interval, error) {
+	loc, err := p(i)
+	if err != nil {
+		return i, err
+	}
+	return i.In(loc).Round(d)
+}
+
+// WalkWith is like Walk, but first moves Start and End to the Location chosen by the policy, instead of returning an error when they differ
+func (i Interval) WalkWith(d Duration, p LocationPolicy) (<-chan Interval, error) {
+	loc, err := p(i)
+	if err != nil {
+		return nil, err
+	}
+	return i.In(loc).Walk(d)
+}
@@ -0,0 +1,120 @@
+package timewalker
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestRoundWith(t *testing.T) {
+	loc, _ := time.LoadLocation("America/Montreal")
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+	mixed := Interval{
+		Start: parseTime("2000-01-01T12:00:00Z"),
+		End:   parseTime("2000-01-03T12:00:00Z").In(loc),
+	}
+	var testData = []struct {
+		pol LocationPolicy // policy
+		exp string         // expected result, or "" for an error
+	}{
+		{RejectMixedLocations, ""},
+		{StartLocation, "[2000-01-01T00:00:00Z, 2000-01-04T00:00:00Z)"},
+		{EndLocation, "[2000-01-01T00:00:00-05:00, 2000-01-04T00:00:00-05:00)"},
+		{ExplicitLocation(tokyo), "[2000-01-01T00:00:00+09:00, 2000-01-04T00:00:00+09:00)"},
+	}
+	for _, tt := range testData {
+		actual, err := mixed.RoundWith(Day, tt.pol)
+		if tt.exp == "" {
+			if err == nil {
+				t.Errorf("%v.RoundWith(Day): expected an error, got %v", mixed, actual)
+			}
+			continue
+		}
+		if err != nil || actual.String() != tt.exp || actual.Start.Location() != actual.End.Location() {
+			t.Errorf("%v.RoundWith(Day): \nexp: %v, \nact: %v, %v", mixed, tt.exp, actual, err)
+		}
+	}
+
+	// unbounded sides use the other side's Location
+	since := Interval{Start: parseTime("2000-01-01T12:00:00Z").In(loc), Bounds: EndUnbounded}
+	for _, p := range []LocationPolicy{RejectMixedLocations, StartLocation, EndLocation} {
+		if l, err := p(since); err != nil || l != loc {
+			t.Errorf("policy(%v): exp: %v act: %v, %v", since, loc, l, err)
+		}
+	}
+}
+
+func TestWalkWith(t *testing.T) {
+	loc, _ := time.LoadLocation("America/Montreal")
+	mixed := Interval{Start: parseTime("2000-01-01T12:00:00Z"), End: parseTime("2000-01-03T12:00:00Z").In(loc)}
+	if _, err := mixed.Walk(Day); err == nil {
+		t.Error("Walk: expected an error for mixed Locations")
+	}
+	if _, err := mixed.WalkWith(Day, RejectMixedLocations); err == nil {
+		t.Error("WalkWith(RejectMixedLocations): expected an error for mixed Locations")
+	}
+	ch, err := mixed.WalkWith(Day, EndLocation)
+	if err != nil {
+		t.Fatalf("WalkWith(EndLocation): unexpected error: %v", err)
+	}
+	count := 0
+	for day := range ch {
+		if day.Start.Location() != loc || day.End.Location() != loc {
+			t.Errorf("WalkWith(EndLocation): exp Location %v act: %v", loc, day)
+		}
+		count++
+	}
+	if count != 3 {
+		t.Errorf("WalkWith(EndLocation): exp 3 days act: %d", count)
+	}
+}
+
+// Walk rounds a and b in their own Locations, walks in a's Location, and stops at b's rounded instant
+func TestWalkMixedLocations(t *testing.T) {
+	loc, _ := time.LoadLocation("America/Montreal")
+	var testData = []struct {
+		a, b time.Time // walk boundaries
+		exp  []string  // expected times
+	}{
+		{ // b floors to 2001-02-03T00:00Z, i.e. Feb 2 19:00 in Montreal: Feb 2 is still produced
+			a:   parseTime("2001-02-01T12:00:00Z").In(loc),
+			b:   parseTime("2001-02-03T02:00:00Z"),
+			exp: []string{"2001-02-01T00:00:00-05:00", "2001-02-02T00:00:00-05:00"},
+		}, { // b is Feb 2 21:00 in Montreal and floors to 2001-02-02T05:00Z: Feb 2 UTC is still produced
+			a:   parseTime("2001-02-01T12:00:00Z"),
+			b:   parseTime("2001-02-03T02:00:00Z").In(loc),
+			exp: []string{"2001-02-01T00:00:00Z", "2001-02-02T00:00:00Z"},
+		}, { // b floors to 2001-02-03T05:00Z, after Feb 3 UTC
+			a:   parseTime("2001-02-01T12:00:00Z"),
+			b:   parseTime("2001-02-03T12:00:00Z").In(loc),
+			exp: []string{"2001-02-01T00:00:00Z", "2001-02-02T00:00:00Z", "2001-02-03T00:00:00Z"},
+		},
+	}
+	for _, tt := range testData {
+		ch, _ := Walk(tt.a, tt.b, Day)
+		var actual []string
+		for t := range ch {
+			actual = append(actual, t.Format(time.RFC3339))
+		}
+		if fmt.Sprint(actual) != fmt.Sprint(tt.exp) {
+			t.Errorf("Walk(%v, %v, Day): \nexp: %v, \nact: %v", tt.a, tt.b, tt.exp, actual)
+		}
+	}
+}
+
+func ExampleInterval_RoundWith() {
+	loc, _ := time.LoadLocation("America/Montreal")
+	i := parseIntvl("2000-01-01T12:00:00Z", "2001-01-01T12:00:00Z")
+	i.End = i.End.In(loc)
+
+	_, err := i.RoundWith(Day, RejectMixedLocations)
+	fmt.Println(err)
+	ri, _ := i.RoundWith(Day, StartLocation)
+	fmt.Println(ri)
+	ri, _ = i.RoundWith(Day, EndLocation)
+	fmt.Println(ri)
+	// Output:
+	// Interval boundaries have in different time.Location: UTC!=America/Montreal, [2000-01-01T12:00:00Z, 2001-01-01T07:00:00-05:00)
+	// [2000-01-01T00:00:00Z, 2001-01-02T00:00:00Z)
+	// [2000-01-01T00:00:00-05:00, 2001-01-02T00:00:00-05:00)
+}
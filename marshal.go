@@ -0,0 +1,182 @@
+package timewalker
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/daneroo/timewalker/tzif"
+)
+
+// ParseDuration returns the Duration with the given name, e.g. "Day", as produced by Duration.String. The match is case-insensitive.
+func ParseDuration(s string) (Duration, error) {
+	for _, d := range []Duration{Day, Month, Year, Week, Quarter} {
+		if strings.EqualFold(s, d.String()) {
+			return d, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid Duration: %q", s)
+}
+
+// MarshalText implements encoding.TextMarshaler, a Duration is serialized as its name
+func (d Duration) MarshalText() ([]byte, error) {
+	if _, err := ParseDuration(d.String()); err != nil {
+		return nil, fmt.Errorf("invalid Duration: %d", int(d))
+	}
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, see ParseDuration
+func (d *Duration) UnmarshalText(text []byte) error {
+	parsed, err := ParseDuration(string(text))
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, a Duration is serialized as a JSON string holding its name
+func (d Duration) MarshalJSON() ([]byte, error) {
+	text, err := d.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(text))
+}
+
+// UnmarshalJSON implements json.Unmarshaler. For compatibility, the bare integers previously produced by encoding/json are also accepted.
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	if n, err := strconv.Atoi(string(data)); err == nil {
+		return d.UnmarshalText([]byte(Duration(n).String()))
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("invalid Duration: %s", data)
+	}
+	return d.UnmarshalText([]byte(s))
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler, using the same representation as MarshalText
+func (d Duration) MarshalBinary() ([]byte, error) {
+	return d.MarshalText()
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler
+func (d *Duration) UnmarshalBinary(data []byte) error {
+	return d.UnmarshalText(data)
+}
+
+// MarshalText implements encoding.TextMarshaler. An Interval is serialized as an ISO 8601 start/end interval,
+// where each time is suffixed with its zone name, e.g. 2001-01-01T00:00:00-05:00[America/Montreal], so the Location survives a round trip.
+// Times in UTC, Local, or in a zone which LoadZone cannot load, such as a fixed zone, are not suffixed, and unbounded boundaries are written .. as in ISO8601.
+// ISO 8601 intervals are half-open, so an exclusive Start or inclusive End is an error: normalize it with HalfOpen first.
+func (i Interval) MarshalText() ([]byte, error) {
+	if err := i.checkHalfOpen(); err != nil {
+		return nil, err
+	}
+	return []byte(i.format(formatISOTime)), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, see ParseInterval
+func (i *Interval) UnmarshalText(text []byte) error {
+	parsed, err := ParseInterval(string(text))
+	if err != nil {
+		return err
+	}
+	*i = parsed
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, an Interval is serialized as a JSON string, see MarshalText
+func (i Interval) MarshalJSON() ([]byte, error) {
+	text, err := i.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(text))
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (i *Interval) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("invalid Interval: %s", data)
+	}
+	return i.UnmarshalText([]byte(s))
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler, using the same representation as MarshalText
+func (i Interval) MarshalBinary() ([]byte, error) {
+	return i.MarshalText()
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler
+func (i *Interval) UnmarshalBinary(data []byte) error {
+	return i.UnmarshalText(data)
+}
+
+// LoadZone loads the zone named in the suffix of a time when unmarshaling an Interval, see Interval.MarshalText.
+// By default, it looks up the embedded and registered zones of the tzif package, so that Intervals unmarshal on hosts without a zone database,
+// and falls back to time.LoadLocation. Replace it, e.g. in an init function, to resolve zone names differently.
+var LoadZone = loadZone
+
+// loadZone loads a zone from the embedded zones if possible, or else from the host's zone database
+func loadZone(name string) (*time.Location, error) {
+	if loc, err := tzif.LoadLocation(name); err == nil {
+		return loc, nil
+	}
+	return time.LoadLocation(name)
+}
+
+// formatISOTime formats t in RFC 3339 format, suffixed with its zone name when it has a meaningful one, which LoadZone can load back.
+// Fixed zones, e.g. PST as parsed by time.Parse, are left with their bare offset.
+func formatISOTime(t time.Time) string {
+	s := t.Format(time.RFC3339Nano)
+	if name := t.Location().String(); name != "" && name != "UTC" && name != "Local" {
+		if _, err := LoadZone(name); err == nil {
+			s += "[" + name + "]"
+		}
+	}
+	return s
+}
+
+// parseISOTime parses the time part of an ISO 8601 interval, in RFC 3339 format, optionally suffixed with a zone name as formatted by formatISOTime. When a zone name is present, the offset must match that zone at that instant.
+// Without a zone name, the time is in UTC for Z or a zero offset, or else in a fixed zone with the literal's offset, never in the host's Local zone.
+// A calendar date, e.g. 2001-02-03, is the start of that day, in UTC or in the named zone, see Date.In.
+func parseISOTime(s string) (time.Time, error) {
+	name := ""
+	if strings.HasSuffix(s, "]") {
+		open := strings.LastIndex(s, "[")
+		if open < 0 {
+			return time.Time{}, fmt.Errorf("invalid ISO 8601 interval time %q: unbalanced ']'", s)
+		}
+		s, name = s[:open], s[open+1:len(s)-1]
+	}
+	if d, err := ParseDate(s); err == nil && len(s) == len("2006-01-02") {
+		loc := time.UTC
+		if name != "" {
+			if loc, err = LoadZone(name); err != nil {
+				return time.Time{}, fmt.Errorf("invalid ISO 8601 interval time %q: %v", s, err)
+			}
+		}
+		return d.In(loc), nil
+	}
+	t, err := time.ParseInLocation(time.RFC3339Nano, s, time.UTC)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid ISO 8601 interval time %q: %v", s, err)
+	}
+	if name == "" {
+		return t, nil
+	}
+	loc, err := LoadZone(name)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid ISO 8601 interval time %q: %v", s, err)
+	}
+	if t, err = ResolveOffset(t, loc); err != nil {
+		return time.Time{}, fmt.Errorf("invalid ISO 8601 interval time %q: %v", s, err)
+	}
+	return t, nil
+}
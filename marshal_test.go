@@ -0,0 +1,163 @@
+package timewalker
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/daneroo/timewalker/tzif"
+)
+
+func TestDurationMarshaling(t *testing.T) {
+	for _, d := range []Duration{Day, Month, Year} {
+		data, err := json.Marshal(d)
+		if err != nil || string(data) != `"`+d.String()+`"` {
+			t.Errorf("json.Marshal(%s): %s, %v", d, data, err)
+		}
+		var actual Duration
+		if err := json.Unmarshal(data, &actual); err != nil || actual != d {
+			t.Errorf("json.Unmarshal(%s): %s, %v", data, actual, err)
+		}
+		bin, _ := d.MarshalBinary()
+		if err := actual.UnmarshalBinary(bin); err != nil || actual != d {
+			t.Errorf("UnmarshalBinary(%s): %s, %v", bin, actual, err)
+		}
+	}
+
+	var d Duration
+	// legacy integer encoding, and case-insensitive names
+	for inp, exp := range map[string]Duration{`1`: Month, `"year"`: Year, `"DAY"`: Day} {
+		if err := json.Unmarshal([]byte(inp), &d); err != nil || d != exp {
+			t.Errorf("json.Unmarshal(%s): exp: %s act: %s, %v", inp, exp, d, err)
+		}
+	}
+	for _, inp := range []string{`42`, `"Fortnight"`, `{}`} {
+		if err := json.Unmarshal([]byte(inp), &d); err == nil {
+			t.Errorf("json.Unmarshal(%s): expected an error", inp)
+		}
+	}
+	if _, err := json.Marshal(Duration(42)); err == nil {
+		t.Error("json.Marshal(Duration(42)): expected an error")
+	}
+}
+
+func TestIntervalMarshaling(t *testing.T) {
+	loc, _ := time.LoadLocation("America/Montreal")
+	var testData = []struct {
+		inp Interval // input
+		exp string   // expected JSON
+	}{
+		{
+			inp: parseIntvl("2001-01-01T00:00:00Z", "2001-02-01T00:00:00.5Z"),
+			exp: `"2001-01-01T00:00:00Z/2001-02-01T00:00:00.5Z"`,
+		}, {
+			inp: Interval{
+				Start: time.Date(2001, time.January, 1, 0, 0, 0, 0, loc),
+				End:   time.Date(2001, time.July, 1, 0, 0, 0, 0, loc),
+			},
+			exp: `"2001-01-01T00:00:00-05:00[America/Montreal]/2001-07-01T00:00:00-04:00[America/Montreal]"`,
+		}, {
+			inp: Interval{
+				Start: time.Date(2001, time.January, 1, 0, 0, 0, 0, time.FixedZone("", 3600)),
+				End:   time.Date(2001, time.July, 1, 0, 0, 0, 0, time.UTC),
+			},
+			exp: `"2001-01-01T00:00:00+01:00/2001-07-01T00:00:00Z"`,
+		},
+	}
+	for _, tt := range testData {
+		data, err := json.Marshal(tt.inp)
+		if err != nil || string(data) != tt.exp {
+			t.Errorf("json.Marshal(%v): \nexp: %s, \nact: %s, %v", tt.inp, tt.exp, data, err)
+		}
+		var actual Interval
+		if err := json.Unmarshal(data, &actual); err != nil {
+			t.Errorf("json.Unmarshal(%s): unexpected error: %v", data, err)
+		}
+		if !actual.Start.Equal(tt.inp.Start) || !actual.End.Equal(tt.inp.End) ||
+			actual.Start.Location().String() != tt.inp.Start.Location().String() && tt.inp.Start.Location().String() != "" {
+			t.Errorf("json.Unmarshal(%s): \nexp: %v, \nact: %v", data, tt.inp, actual)
+		}
+		bin, _ := tt.inp.MarshalBinary()
+		if err := actual.UnmarshalBinary(bin); err != nil || !actual.Start.Equal(tt.inp.Start) {
+			t.Errorf("UnmarshalBinary(%s): %v, %v", bin, actual, err)
+		}
+	}
+
+	var i Interval
+	for _, inp := range []string{
+		`42`,
+		`"2001-01-01T00:00:00-04:00[America/Montreal]/P1D"`,
+		`"2001-01-01T00:00:00-05:00[Nowhere/Special]/P1D"`,
+		`"2001-01-01T00:00:00-05:00America/Montreal]/P1D"`,
+	} {
+		if err := json.Unmarshal([]byte(inp), &i); err == nil {
+			t.Errorf("json.Unmarshal(%s): expected an error", inp)
+		}
+	}
+}
+
+func TestIntervalUnmarshalZones(t *testing.T) {
+	// a zone only known to the tzif registry, as in a FROM scratch image
+	f := tzif.File{Version: 2, Types: []tzif.ZoneType{{Offset: -5 * 3600, Name: "XST"}}, Footer: "XST5"}
+	if err := tzif.Register("Test/Unmarshal", f.Encode()); err != nil {
+		t.Fatal(err)
+	}
+	const text = "2001-01-01T00:00:00-05:00[Test/Unmarshal]/2001-01-02T00:00:00-05:00[Test/Unmarshal]"
+	var i Interval
+	if err := i.UnmarshalText([]byte(text)); err != nil || i.Start.Location().String() != "Test/Unmarshal" {
+		t.Errorf("UnmarshalText(%s): unexpected %v, %v", text, i, err)
+	}
+
+	// a replaced LoadZone is used for zone names
+	defer func(load func(string) (*time.Location, error)) { LoadZone = load }(LoadZone)
+	var loaded []string
+	LoadZone = func(name string) (*time.Location, error) {
+		loaded = append(loaded, name)
+		return time.FixedZone(name, -5*3600), nil
+	}
+	const other = "2001-01-01T00:00:00-05:00[Elsewhere/Custom]/P1D"
+	if err := i.UnmarshalText([]byte(other)); err != nil || i.Start.Location().String() != "Elsewhere/Custom" || len(loaded) != 1 {
+		t.Errorf("UnmarshalText(%s) with a replaced LoadZone: unexpected %v, %v, %v", other, i, loaded, err)
+	}
+}
+
+func TestIntervalMarshalFixedZone(t *testing.T) {
+	// time.Parse makes up fixed zones named after abbreviations, which cannot be loaded back
+	start, err := time.Parse("2006-01-02 15:04 MST", "2006-01-02 15:04 PST")
+	if err != nil {
+		t.Fatal(err)
+	}
+	i := Interval{Start: start, End: start.Add(time.Hour)}
+	data, err := json.Marshal(i)
+	if err != nil || strings.Contains(string(data), "[PST]") {
+		t.Fatalf("json.Marshal(%v): %s, %v", i, data, err)
+	}
+	var rt Interval
+	if err := json.Unmarshal(data, &rt); err != nil || !rt.Start.Equal(i.Start) || !rt.End.Equal(i.End) {
+		t.Errorf("json.Unmarshal(%s): round trip: %v, %v", data, rt, err)
+	}
+}
+
+func ExampleInterval_MarshalJSON() {
+	loc, _ := time.LoadLocation("America/Montreal")
+	report := struct {
+		Period Interval
+		Step   Duration
+	}{
+		Period: Interval{
+			Start: time.Date(2001, time.January, 1, 0, 0, 0, 0, loc),
+			End:   time.Date(2002, time.January, 1, 0, 0, 0, 0, loc),
+		},
+		Step: Month,
+	}
+	data, _ := json.Marshal(report)
+	fmt.Println(string(data))
+
+	json.Unmarshal(data, &report)
+	fmt.Println(report.Period.Start.Location())
+	// Output:
+	// {"Period":"2001-01-01T00:00:00-05:00[America/Montreal]/2002-01-01T00:00:00-05:00[America/Montreal]","Step":"Month"}
+	// America/Montreal
+}
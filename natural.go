@@ -0,0 +1,238 @@
+package timewalker
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseNatural parses a loose English phrase into an Interval, relative to the reference time now, in loc (or now's Location when loc is nil).
+// Intervals are steps of a Duration, rounded with Floor, e.g. for now on Thursday 2024-03-07:
+//
+//	today, yesterday, tomorrow                     the Day of now, before or after
+//	this week, last month, next quarter, this year the step of the Duration containing now, before or after; weeks start on Monday
+//	last 3 months, past 2 weeks, next 10 days      the N complete steps before the one containing now, or after it
+//	monday, last friday, next sunday               the latest such Day, today included, or the one strictly before or after today
+//	2024, March 2024, Mar 2024, march              a Year, or a Month, in the year of now when omitted
+//	Q3 2023, 2023-Q3, q1                           a Quarter, in the year of now when omitted
+//	2024-W10, 2024-03, 2024-03-07                  an ISO 8601 Week, a Month, or a Day
+//	since monday, since March 2024                 from the start of the phrase to the end of today
+//
+// Phrases are case-insensitive, and errors report the position of the problem.
+func ParseNatural(s string, now time.Time, loc *time.Location) (Interval, error) {
+	if loc != nil {
+		now = now.In(loc)
+	}
+	p := &naturalParser{phrase: s, now: now}
+	p.tokenize()
+	if len(p.words) == 0 {
+		return Interval{}, p.errorf(0, "empty phrase")
+	}
+	if p.words[0].text == "since" {
+		if len(p.words) == 1 {
+			return Interval{}, p.errorf(len(s), "expected a phrase after since")
+		}
+		i, err := p.parse(p.words[1:])
+		if err != nil {
+			return Interval{}, err
+		}
+		end := Day.Ceil(now)
+		if end.Equal(now) {
+			end = Day.AddTo(end)
+		}
+		if !i.Start.Before(end) {
+			return Interval{}, p.errorf(p.words[1].pos, "%q starts after today", s[p.words[1].pos:])
+		}
+		return Interval{Start: i.Start, End: end}, nil
+	}
+	return p.parse(p.words)
+}
+
+type naturalWord struct {
+	text string // lower case
+	pos  int    // byte position in the phrase
+}
+
+type naturalParser struct {
+	phrase string
+	now    time.Time
+	words  []naturalWord
+}
+
+func (p *naturalParser) errorf(pos int, format string, args ...interface{}) error {
+	return fmt.Errorf("invalid interval phrase %q: %s at position %d", p.phrase, fmt.Sprintf(format, args...), pos)
+}
+
+func (p *naturalParser) tokenize() {
+	start := -1
+	for k := 0; k <= len(p.phrase); k++ {
+		space := k == len(p.phrase) || p.phrase[k] == ' ' || p.phrase[k] == '\t' || p.phrase[k] == ','
+		if space && start >= 0 {
+			p.words = append(p.words, naturalWord{strings.ToLower(p.phrase[start:k]), start})
+			start = -1
+		} else if !space && start < 0 {
+			start = k
+		}
+	}
+}
+
+// step returns the step of d containing t, shifted by n steps
+func step(d Duration, t time.Time, n int) Interval {
+	start, _ := d.AddN(d.Floor(t), n, OverflowNormalize)
+	return Interval{Start: start, End: d.AddTo(start)}
+}
+
+// naturalUnits are the Durations by name, singular and plural
+var naturalUnits = map[string]Duration{
+	"day": Day, "days": Day, "week": Week, "weeks": Week, "month": Month, "months": Month,
+	"quarter": Quarter, "quarters": Quarter, "year": Year, "years": Year,
+}
+
+func (p *naturalParser) parse(words []naturalWord) (Interval, error) {
+	w := words[0]
+	switch len(words) {
+	case 1:
+		return p.single(w)
+	case 2:
+		if shift, ok := map[string]int{"this": 0, "last": -1, "next": 1}[w.text]; ok {
+			if d, ok := naturalUnits[words[1].text]; ok && !strings.HasSuffix(words[1].text, "s") {
+				return step(d, p.now, shift), nil
+			}
+			if wd, ok := weekdayNamed(words[1].text); ok && shift != 0 {
+				return p.weekday(wd, shift), nil
+			}
+			return Interval{}, p.errorf(words[1].pos, "expected a unit or a weekday after %s, got %q", w.text, words[1].text)
+		}
+		if month, ok := monthNamed(w.text); ok {
+			year, err := p.year(words[1])
+			if err != nil {
+				return Interval{}, err
+			}
+			return step(Month, time.Date(year, month, 1, 0, 0, 0, 0, p.now.Location()), 0), nil
+		}
+		if q, ok := quarterNamed(w.text); ok {
+			year, err := p.year(words[1])
+			if err != nil {
+				return Interval{}, err
+			}
+			return p.quarter(year, q), nil
+		}
+		if q, ok := quarterNamed(words[1].text); ok {
+			year, err := p.year(w)
+			if err != nil {
+				return Interval{}, err
+			}
+			return p.quarter(year, q), nil
+		}
+		return Interval{}, p.errorf(w.pos, "unexpected %q", w.text)
+	case 3:
+		sign, ok := map[string]int{"last": -1, "past": -1, "next": 1}[w.text]
+		if !ok {
+			return Interval{}, p.errorf(w.pos, "expected last, past or next, got %q", w.text)
+		}
+		n, err := strconv.Atoi(words[1].text)
+		if err != nil || n < 1 {
+			return Interval{}, p.errorf(words[1].pos, "expected a positive number, got %q", words[1].text)
+		}
+		d, ok := naturalUnits[words[2].text]
+		if !ok {
+			return Interval{}, p.errorf(words[2].pos, "unknown unit %q", words[2].text)
+		}
+		if sign < 0 {
+			return Interval{Start: step(d, p.now, -n).Start, End: d.Floor(p.now)}, nil
+		}
+		return Interval{Start: step(d, p.now, 1).Start, End: step(d, p.now, n).End}, nil
+	}
+	return Interval{}, p.errorf(words[3].pos, "unexpected %q", words[3].text)
+}
+
+// single parses a phrase of a single word
+func (p *naturalParser) single(w naturalWord) (Interval, error) {
+	loc := p.now.Location()
+	switch w.text {
+	case "today":
+		return step(Day, p.now, 0), nil
+	case "yesterday":
+		return step(Day, p.now, -1), nil
+	case "tomorrow":
+		return step(Day, p.now, 1), nil
+	}
+	if wd, ok := weekdayNamed(w.text); ok {
+		return p.weekday(wd, 0), nil
+	}
+	if month, ok := monthNamed(w.text); ok {
+		return step(Month, time.Date(p.now.Year(), month, 1, 0, 0, 0, 0, loc), 0), nil
+	}
+	if q, ok := quarterNamed(w.text); ok {
+		return p.quarter(p.now.Year(), q), nil
+	}
+	if year, err := strconv.Atoi(w.text); err == nil && len(w.text) == 4 {
+		return step(Year, time.Date(year, time.January, 1, 0, 0, 0, 0, loc), 0), nil
+	}
+	if len(w.text) == 7 && strings.HasPrefix(w.text[4:], "-q") {
+		year, err := p.year(naturalWord{w.text[:4], w.pos})
+		q, ok := quarterNamed(w.text[5:])
+		if err == nil && ok {
+			return p.quarter(year, q), nil
+		}
+	}
+	for _, f := range []struct {
+		format string
+		d      Duration
+	}{{"%Y-%m-%d", Day}, {"%G-W%V", Week}, {"%Y-%m", Month}} {
+		if t, err := Strptime(f.format, strings.ToUpper(w.text), loc); err == nil {
+			return step(f.d, t, 0), nil
+		}
+	}
+	return Interval{}, p.errorf(w.pos, "unexpected %q", w.text)
+}
+
+func (p *naturalParser) year(w naturalWord) (int, error) {
+	year, err := strconv.Atoi(w.text)
+	if err != nil || len(w.text) != 4 {
+		return 0, p.errorf(w.pos, "expected a year, got %q", w.text)
+	}
+	return year, nil
+}
+
+func (p *naturalParser) quarter(year, q int) Interval {
+	return step(Quarter, time.Date(year, time.Month(3*q-2), 1, 0, 0, 0, 0, p.now.Location()), 0)
+}
+
+// weekday returns the Day of the latest wd, today included, when shift is 0, or the one strictly before (-1) or after (1) today
+func (p *naturalParser) weekday(wd time.Weekday, shift int) Interval {
+	days := (int(p.now.Weekday()) - int(wd) + 7) % 7 // days back to the latest wd
+	switch {
+	case shift < 0 && days == 0:
+		days = 7
+	case shift > 0:
+		days -= 7
+	}
+	return step(Day, p.now, -days)
+}
+
+func weekdayNamed(s string) (time.Weekday, bool) {
+	for k := range English.Weekdays {
+		if s == strings.ToLower(English.Weekdays[k]) || s == strings.ToLower(English.ShortWeekdays[k]) {
+			return time.Weekday(k), true
+		}
+	}
+	return 0, false
+}
+
+func monthNamed(s string) (time.Month, bool) {
+	for k := range English.Months {
+		if s == strings.ToLower(English.Months[k]) || s == strings.ToLower(English.ShortMonths[k]) || (k == 8 && s == "sept") {
+			return time.Month(k + 1), true
+		}
+	}
+	return 0, false
+}
+
+func quarterNamed(s string) (int, bool) {
+	if len(s) == 2 && s[0] == 'q' && s[1] >= '1' && s[1] <= '4' {
+		return int(s[1] - '0'), true
+	}
+	return 0, false
+}
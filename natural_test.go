@@ -0,0 +1,102 @@
+package timewalker
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestParseNatural(t *testing.T) {
+	// Thursday
+	now := parseTime("2024-03-07T15:04:05Z")
+	loc, _ := time.LoadLocation("America/Montreal")
+	var testData = []struct {
+		inp string         // phrase
+		loc *time.Location // Location
+		exp string         // expected interval
+	}{
+		{"today", nil, "[2024-03-07T00:00:00Z, 2024-03-08T00:00:00Z)"},
+		{"Yesterday", nil, "[2024-03-06T00:00:00Z, 2024-03-07T00:00:00Z)"},
+		{"tomorrow", loc, "[2024-03-08T00:00:00-05:00, 2024-03-09T00:00:00-05:00)"},
+		{"this week", nil, "[2024-03-04T00:00:00Z, 2024-03-11T00:00:00Z)"},
+		{"last week", nil, "[2024-02-26T00:00:00Z, 2024-03-04T00:00:00Z)"},
+		{"next month", loc, "[2024-04-01T00:00:00-04:00, 2024-05-01T00:00:00-04:00)"},
+		{"this quarter", nil, "[2024-01-01T00:00:00Z, 2024-04-01T00:00:00Z)"},
+		{"last year", nil, "[2023-01-01T00:00:00Z, 2024-01-01T00:00:00Z)"},
+		{"last 3 months", nil, "[2023-12-01T00:00:00Z, 2024-03-01T00:00:00Z)"},
+		{"past 2 weeks", nil, "[2024-02-19T00:00:00Z, 2024-03-04T00:00:00Z)"},
+		{"next 10 days", nil, "[2024-03-08T00:00:00Z, 2024-03-18T00:00:00Z)"},
+		{"last 1 quarter", nil, "[2023-10-01T00:00:00Z, 2024-01-01T00:00:00Z)"},
+		{"thursday", nil, "[2024-03-07T00:00:00Z, 2024-03-08T00:00:00Z)"},
+		{"monday", nil, "[2024-03-04T00:00:00Z, 2024-03-05T00:00:00Z)"},
+		{"last thu", nil, "[2024-02-29T00:00:00Z, 2024-03-01T00:00:00Z)"},
+		{"next Thursday", nil, "[2024-03-14T00:00:00Z, 2024-03-15T00:00:00Z)"},
+		{"next friday", nil, "[2024-03-08T00:00:00Z, 2024-03-09T00:00:00Z)"},
+		{"2024", nil, "[2024-01-01T00:00:00Z, 2025-01-01T00:00:00Z)"},
+		{"March 2024", loc, "[2024-03-01T00:00:00-05:00, 2024-04-01T00:00:00-04:00)"},
+		{"sept 2023", nil, "[2023-09-01T00:00:00Z, 2023-10-01T00:00:00Z)"},
+		{"december", nil, "[2024-12-01T00:00:00Z, 2025-01-01T00:00:00Z)"},
+		{"Q3 2023", nil, "[2023-07-01T00:00:00Z, 2023-10-01T00:00:00Z)"},
+		{"2023 q4", nil, "[2023-10-01T00:00:00Z, 2024-01-01T00:00:00Z)"},
+		{"2023-Q2", nil, "[2023-04-01T00:00:00Z, 2023-07-01T00:00:00Z)"},
+		{"q1", nil, "[2024-01-01T00:00:00Z, 2024-04-01T00:00:00Z)"},
+		{"2024-W10", nil, "[2024-03-04T00:00:00Z, 2024-03-11T00:00:00Z)"},
+		{"2020-w53", nil, "[2020-12-28T00:00:00Z, 2021-01-04T00:00:00Z)"},
+		{"2024-02", nil, "[2024-02-01T00:00:00Z, 2024-03-01T00:00:00Z)"},
+		{"2024-02-29", nil, "[2024-02-29T00:00:00Z, 2024-03-01T00:00:00Z)"},
+		{"since Monday", nil, "[2024-03-04T00:00:00Z, 2024-03-08T00:00:00Z)"},
+		{"since  march 2024", loc, "[2024-03-01T00:00:00-05:00, 2024-03-08T00:00:00-05:00)"},
+		{"since last year", nil, "[2023-01-01T00:00:00Z, 2024-03-08T00:00:00Z)"},
+	}
+	for _, tt := range testData {
+		actual, err := ParseNatural(tt.inp, now, tt.loc)
+		if err != nil || actual.String() != tt.exp {
+			t.Errorf("ParseNatural(%q): \nexp: %v, \nact: %v, %v", tt.inp, tt.exp, actual, err)
+		}
+	}
+}
+
+func TestParseNaturalErrors(t *testing.T) {
+	now := parseTime("2024-03-07T15:04:05Z")
+	var testData = []struct {
+		inp string // phrase
+		exp string // expected error, after the common prefix
+	}{
+		{"", "empty phrase at position 0"},
+		{"someday", `unexpected "someday" at position 0`},
+		{"last fortnight", `expected a unit or a weekday after last, got "fortnight" at position 5`},
+		{"this monday", `expected a unit or a weekday after this, got "monday" at position 5`},
+		{"last three months", `expected a positive number, got "three" at position 5`},
+		{"last 0 days", `expected a positive number, got "0" at position 5`},
+		{"last 3 fortnights", `unknown unit "fortnights" at position 7`},
+		{"previous 3 days", `expected last, past or next, got "previous" at position 0`},
+		{"march 24", `expected a year, got "24" at position 6`},
+		{"q5 2024", `unexpected "q5" at position 0`},
+		{"2023-W53", `unexpected "2023-w53" at position 0`},
+		{"since", "expected a phrase after since at position 5"},
+		{"since next week", `"next week" starts after today at position 6`},
+		{"the last 3 days", `unexpected "days" at position 11`},
+	}
+	for _, tt := range testData {
+		_, err := ParseNatural(tt.inp, now, nil)
+		exp := fmt.Sprintf("invalid interval phrase %q: %s", tt.inp, tt.exp)
+		if err == nil || err.Error() != exp {
+			t.Errorf("ParseNatural(%q): \nexp: %v, \nact: %v", tt.inp, exp, err)
+		}
+	}
+}
+
+func ExampleParseNatural() {
+	loc, _ := time.LoadLocation("America/Montreal")
+	now := time.Date(2024, time.March, 7, 10, 0, 0, 0, loc)
+	for _, phrase := range []string{"yesterday", "last 3 months", "Q3 2023", "2024-W10", "since Monday"} {
+		i, _ := ParseNatural(phrase, now, loc)
+		fmt.Printf("%-14s %v\n", phrase, i)
+	}
+	// Output:
+	// yesterday      [2024-03-06T00:00:00-05:00, 2024-03-07T00:00:00-05:00)
+	// last 3 months  [2023-12-01T00:00:00-05:00, 2024-03-01T00:00:00-05:00)
+	// Q3 2023        [2023-07-01T00:00:00-04:00, 2023-10-01T00:00:00-04:00)
+	// 2024-W10       [2024-03-04T00:00:00-05:00, 2024-03-11T00:00:00-04:00)
+	// since Monday   [2024-03-04T00:00:00-05:00, 2024-03-08T00:00:00-05:00)
+}
@@ -0,0 +1,48 @@
+package timewalker
+
+import (
+	"fmt"
+	"time"
+)
+
+// ParseRFC3339In parses an RFC 3339 time directly into loc, e.g. 2001-02-03T07:45:56-05:00 into America/Montreal,
+// checking that the offset of the literal is the offset of loc at that instant, see ResolveOffset.
+// Unlike time.Parse, the result does not depend on the host's Local zone.
+func ParseRFC3339In(s string, loc *time.Location) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return ResolveOffset(t, loc)
+}
+
+// ParseTimeIn parses a time as given on a command line or in a query: a date, e.g. 2001-02-03, which is the start of that day in loc,
+// a wall clock time, e.g. 2001-02-03T04:05:06, in loc, or an RFC 3339 instant, which is then moved to loc.
+// A wall clock time which is ambiguous or nonexistent in loc is resolved with DisambiguateShiftForward.
+func ParseTimeIn(s string, loc *time.Location) (time.Time, error) {
+	if loc == nil {
+		return time.Time{}, fmt.Errorf("invalid Location: nil")
+	}
+	if d, err := ParseDate(s); err == nil {
+		return d.In(loc), nil
+	}
+	if dt, err := ParseDateTime(s); err == nil {
+		return dt.In(loc, DisambiguateShiftForward)
+	}
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time %q: expected a date, a wall clock time or an RFC 3339 instant", s)
+	}
+	return t.In(loc), nil
+}
+
+// ResolveOffset returns t in loc, provided t's offset is the offset of loc at that instant.
+// It resolves a time with a bare offset, such as parsed from RFC 3339, to a named zone, without changing its wall clock.
+func ResolveOffset(t time.Time, loc *time.Location) (time.Time, error) {
+	_, offset := t.Zone()
+	zt := t.In(loc)
+	if _, zoffset := zt.Zone(); zoffset != offset {
+		return time.Time{}, fmt.Errorf("offset of %s does not match %s: %s", t.Format(time.RFC3339Nano), loc, zt.Format(time.RFC3339Nano))
+	}
+	return zt, nil
+}
@@ -0,0 +1,64 @@
+package timewalker
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseTimeIn(t *testing.T) {
+	loc, _ := time.LoadLocation("America/Montreal")
+	var testData = []struct {
+		inp string // input
+		exp string // expected time in loc, or "" for an error
+	}{
+		{"2020-03-08", "2020-03-08T00:00:00-05:00"},
+		{"2020-03-08T12:30:00", "2020-03-08T12:30:00-04:00"},
+		{"2020-03-08T02:30:00", "2020-03-08T03:30:00-04:00"}, // nonexistent, shifted forward
+		{"2020-03-08T12:30:00Z", "2020-03-08T08:30:00-04:00"},
+		{"2020-03-08T12:30:00.5+01:00", "2020-03-08T07:30:00.5-04:00"},
+		{"yesterday", ""},
+		{"2020-02-30", ""},
+	}
+	for _, tt := range testData {
+		actual, err := ParseTimeIn(tt.inp, loc)
+		if tt.exp == "" {
+			if err == nil {
+				t.Errorf("ParseTimeIn(%s): expected an error, got %v", tt.inp, actual)
+			}
+			continue
+		}
+		if err != nil || actual.Location() != loc || actual.Format(time.RFC3339Nano) != tt.exp {
+			t.Errorf("ParseTimeIn(%s): exp: %s act: %v, %v", tt.inp, tt.exp, actual, err)
+		}
+	}
+	if _, err := ParseTimeIn("2020-03-08", nil); err == nil {
+		t.Errorf("ParseTimeIn: expected an error for a nil Location")
+	}
+}
+
+func TestParseRFC3339In(t *testing.T) {
+	loc, _ := time.LoadLocation("Europe/London")
+	var testData = []struct {
+		inp string // input
+		exp string // expected zone abbreviation, or "" for an error
+	}{
+		{"2001-02-03T12:45:56Z", "GMT"},
+		{"2001-02-03T12:45:56+00:00", "GMT"},
+		{"2001-07-03T12:45:56+01:00", "BST"},
+		{"2001-07-03T12:45:56Z", ""},
+		{"2001-07-03T12:45:56", ""},
+	}
+	for _, tt := range testData {
+		actual, err := ParseRFC3339In(tt.inp, loc)
+		if tt.exp == "" {
+			if err == nil {
+				t.Errorf("ParseRFC3339In(%s): expected an error, got %v", tt.inp, actual)
+			}
+			continue
+		}
+		name, _ := actual.Zone()
+		if err != nil || name != tt.exp || actual.Location() != loc || !actual.Equal(parseTime(tt.inp)) {
+			t.Errorf("ParseRFC3339In(%s): exp: %s act: %v, %v", tt.inp, tt.exp, actual, err)
+		}
+	}
+}
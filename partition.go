@@ -0,0 +1,166 @@
+package timewalker
+
+import (
+	"fmt"
+	"time"
+)
+
+// PartitionStyle selects the template syntax of a Partitioner
+type PartitionStyle int
+
+// Different package constants defining an enum type for PartitionStyle
+const (
+	// HiveStyle keys are key=value path segments, down to the Partitioner's Duration, e.g. year=2024/month=03/day=07,
+	// year=2024/quarter=1, or year=2024/week=10 for ISO 8601 weeks; the template is unused
+	HiveStyle PartitionStyle = iota
+	// StrftimeStyle templates use strftime conversions, e.g. events_%Y_%m or %G-W%V, see Strftime
+	StrftimeStyle
+	// LayoutStyle templates are Go time layouts, e.g. events_2006_01
+	LayoutStyle
+)
+
+// Produces Human readable representations of the PartitionStyle enum values
+func (s PartitionStyle) String() string {
+	str := "Invalid"
+	switch s {
+	case HiveStyle:
+		str = "Hive"
+	case StrftimeStyle:
+		str = "Strftime"
+	case LayoutStyle:
+		str = "Layout"
+	}
+	return str
+}
+
+// hiveFormats are the strftime formats of HiveStyle keys, by Duration
+var hiveFormats = map[Duration]string{
+	Day:     "year=%Y/month=%m/day=%d",
+	Month:   "year=%Y/month=%m",
+	Year:    "year=%Y",
+	Week:    "year=%G/week=%V",
+	Quarter: "year=%Y/quarter=%q",
+}
+
+// keyFormat formats and parses the start of a partition
+type keyFormat interface {
+	format(t time.Time) string
+	parse(key string, loc *time.Location) (time.Time, error)
+}
+
+// Partitioner names the Intervals of a walk, as produced by Interval.Walk, with partition keys for time-partitioned storage,
+// e.g. year=2024/month=03/day=07 or events_2024_03, and maps partition keys back to their Interval.
+// Create it with NewPartitioner: a Partitioner literal validates its template on every call, and its methods return the errors of NewPartitioner.
+type Partitioner struct {
+	Duration Duration
+	Style    PartitionStyle
+	Template string
+	keys     keyFormat
+}
+
+// NewPartitioner returns a Partitioner of the Intervals of d, checking that the template identifies an Interval of d,
+// e.g. a Month template must include the year and month.
+func NewPartitioner(d Duration, style PartitionStyle, template string) (*Partitioner, error) {
+	keys, err := newKeyFormat(d, style, template)
+	if err != nil {
+		return nil, err
+	}
+	return &Partitioner{Duration: d, Style: style, Template: template, keys: keys}, nil
+}
+
+// newKeyFormat returns the keyFormat of a Partitioner, see NewPartitioner
+func newKeyFormat(d Duration, style PartitionStyle, template string) (keyFormat, error) {
+	if _, err := d.MarshalText(); err != nil {
+		return nil, err
+	}
+	var keys keyFormat
+	switch style {
+	case HiveStyle:
+		if template != "" {
+			return nil, fmt.Errorf("Hive partitions do not use a template: %q", template)
+		}
+		keys, _ = parseStrftime(hiveFormats[d])
+	case StrftimeStyle:
+		f, err := parseStrftime(template)
+		if err != nil {
+			return nil, err
+		}
+		keys = f
+	case LayoutStyle:
+		keys = goLayout(template)
+	default:
+		return nil, fmt.Errorf("invalid PartitionStyle: %d", int(style))
+	}
+	// a template identifies the Intervals of d if keys round trip, e.g. for February 3rd, 2001,
+	// and around year boundaries, where ISO weeks belong to the adjacent year, e.g. %Y-W%V names the week of 2024-12-30 2024-W01
+	for _, ref := range partitionReferences(d) {
+		if t, err := keys.parse(keys.format(ref), time.UTC); err != nil || !t.Equal(ref) {
+			return nil, fmt.Errorf("template %q does not identify a %v", template, d)
+		}
+	}
+	return keys, nil
+}
+
+// keyFormat returns the keyFormat built by NewPartitioner, or builds it for a Partitioner literal
+func (p *Partitioner) keyFormat() (keyFormat, error) {
+	if p.keys != nil {
+		return p.keys, nil
+	}
+	return newKeyFormat(p.Duration, p.Style, p.Template)
+}
+
+// partitionReferences returns the starts of the Intervals of d used to validate templates:
+// February 3rd, 2001, and the first and last days of years whose ISO weeks straddle the year boundary
+func partitionReferences(d Duration) []time.Time {
+	refs := []time.Time{d.Floor(time.Date(2001, time.February, 3, 0, 0, 0, 0, time.UTC))}
+	for _, year := range []int{2004, 2009, 2015, 2020, 2021, 2024, 2026} {
+		for _, day := range []time.Time{
+			time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC),
+			time.Date(year, time.January, 4, 0, 0, 0, 0, time.UTC),
+			time.Date(year, time.December, 28, 0, 0, 0, 0, time.UTC),
+			time.Date(year, time.December, 31, 0, 0, 0, 0, time.UTC),
+		} {
+			refs = append(refs, d.Floor(day))
+		}
+	}
+	return refs
+}
+
+// Key returns the partition key of the receiver's interval, which must be a single Duration step, as produced by Interval.Walk
+func (p *Partitioner) Key(i Interval) (string, error) {
+	if i.Bounds != 0 || !p.Duration.Floor(i.Start).Equal(i.Start) || !p.Duration.AddTo(i.Start).Equal(i.End) {
+		return "", fmt.Errorf("%v is not a single %v", i, p.Duration)
+	}
+	keys, err := p.keyFormat()
+	if err != nil {
+		return "", err
+	}
+	return keys.format(i.Start), nil
+}
+
+// Parse returns the Interval of a partition key, in loc
+func (p *Partitioner) Parse(key string, loc *time.Location) (Interval, error) {
+	keys, err := p.keyFormat()
+	if err != nil {
+		return Interval{}, err
+	}
+	t, err := keys.parse(key, loc)
+	if err != nil {
+		return Interval{}, fmt.Errorf("invalid partition key %q: %v", key, err)
+	}
+	if !p.Duration.Floor(t).Equal(t) {
+		return Interval{}, fmt.Errorf("invalid partition key %q: not on a %v boundary", key, p.Duration)
+	}
+	return Interval{Start: t, End: p.Duration.AddTo(t)}, nil
+}
+
+// goLayout is a keyFormat using a Go time layout
+type goLayout string
+
+func (l goLayout) format(t time.Time) string {
+	return t.Format(string(l))
+}
+
+func (l goLayout) parse(key string, loc *time.Location) (time.Time, error) {
+	return time.ParseInLocation(string(l), key, loc)
+}
@@ -0,0 +1,121 @@
+package timewalker
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPartitioner(t *testing.T) {
+	loc, _ := time.LoadLocation("America/Montreal")
+	var testData = []struct {
+		d        Duration       // partition Duration
+		style    PartitionStyle // template style
+		template string         // template
+		i        Interval       // partition
+		exp      string         // expected key
+	}{
+		{Day, HiveStyle, "", parseIntvl("2024-03-07T00:00:00Z", "2024-03-08T00:00:00Z"), "year=2024/month=03/day=07"},
+		{Month, HiveStyle, "", parseIntvl("2024-03-01T00:00:00Z", "2024-04-01T00:00:00Z"), "year=2024/month=03"},
+		{Year, HiveStyle, "", parseIntvl("2024-01-01T00:00:00Z", "2025-01-01T00:00:00Z"), "year=2024"},
+		{Month, StrftimeStyle, "events_%Y_%m", parseIntvl("2024-03-01T00:00:00Z", "2024-04-01T00:00:00Z"), "events_2024_03"},
+		{Day, StrftimeStyle, "100%%/%Y%m%d", parseIntvl("2024-03-07T00:00:00Z", "2024-03-08T00:00:00Z"), "100%/20240307"},
+		{Week, HiveStyle, "", parseIntvl("2024-03-04T00:00:00Z", "2024-03-11T00:00:00Z"), "year=2024/week=10"},
+		{Week, StrftimeStyle, "%G-W%V", parseIntvl("2024-12-30T00:00:00Z", "2025-01-06T00:00:00Z"), "2025-W01"},
+		{Quarter, StrftimeStyle, "q/%Y/%m", parseIntvl("2024-04-01T00:00:00Z", "2024-07-01T00:00:00Z"), "q/2024/04"},
+		{Quarter, StrftimeStyle, "%Y-Q%q", parseIntvl("2024-10-01T00:00:00Z", "2025-01-01T00:00:00Z"), "2024-Q4"},
+		{Quarter, HiveStyle, "", parseIntvl("2024-04-01T00:00:00Z", "2024-07-01T00:00:00Z"), "year=2024/quarter=2"},
+		{Month, LayoutStyle, "events_2006_01", parseIntvl("2024-03-01T00:00:00Z", "2024-04-01T00:00:00Z"), "events_2024_03"},
+		{Day, LayoutStyle, "2006/01/02", parseIntvl("2024-03-10T05:00:00Z", "2024-03-11T04:00:00Z").In(loc), "2024/03/10"},
+	}
+	for _, tt := range testData {
+		p, err := NewPartitioner(tt.d, tt.style, tt.template)
+		if err != nil {
+			t.Errorf("NewPartitioner(%v, %v, %q): unexpected error: %v", tt.d, tt.style, tt.template, err)
+			continue
+		}
+		key, err := p.Key(tt.i)
+		if err != nil || key != tt.exp {
+			t.Errorf("Key(%v): \nexp: %v, \nact: %v, %v", tt.i, tt.exp, key, err)
+		}
+		parsed, err := p.Parse(key, tt.i.Start.Location())
+		if err != nil || parsed != tt.i {
+			t.Errorf("Parse(%q): \nexp: %v, \nact: %v, %v", key, tt.i, parsed, err)
+		}
+	}
+}
+
+func TestPartitionerErrors(t *testing.T) {
+	var testData = []struct {
+		d        Duration       // partition Duration
+		style    PartitionStyle // template style
+		template string         // template
+		exp      string         // expected error
+	}{
+		{Day, HiveStyle, "dt=2006", `Hive partitions do not use a template: "dt=2006"`},
+		{Day, StrftimeStyle, "%Y-%m", `template "%Y-%m" does not identify a Day`},
+		{Month, LayoutStyle, "2006", `template "2006" does not identify a Month`},
+		{Week, StrftimeStyle, "%Y-W%V", `template "%Y-W%V" does not identify a Week`},
+		{Week, StrftimeStyle, "%G-%m-%d", `template "%G-%m-%d" does not identify a Week`},
+		{Day, StrftimeStyle, "%G-%m-%d", `template "%G-%m-%d" does not identify a Day`},
+		{Day, StrftimeStyle, "%Y-%m-%", `invalid strftime format "%Y-%m-%": trailing % at position 6`},
+		{Day, StrftimeStyle, "%Y-%Q-%d", `invalid strftime format "%Y-%Q-%d": unsupported conversion %Q at position 3`},
+		{Quarter, StrftimeStyle, "%Y", `template "%Y" does not identify a Quarter`},
+		{Month, StrftimeStyle, "%Y-Q%q", `template "%Y-Q%q" does not identify a Month`},
+		{Duration(7), HiveStyle, "", "invalid Duration: 7"},
+		{Day, PartitionStyle(7), "", "invalid PartitionStyle: 7"},
+	}
+	for _, tt := range testData {
+		if _, err := NewPartitioner(tt.d, tt.style, tt.template); err == nil || err.Error() != tt.exp {
+			t.Errorf("NewPartitioner(%v, %v, %q): \nexp: %v, \nact: %v", tt.d, tt.style, tt.template, tt.exp, err)
+		}
+	}
+
+	p, _ := NewPartitioner(Month, StrftimeStyle, "events_%Y_%m")
+	if _, err := p.Key(parseIntvl("2024-03-01T00:00:00Z", "2024-03-02T00:00:00Z")); err == nil {
+		t.Errorf("Key: expected an error for a Day Interval")
+	}
+	for _, key := range []string{"events_2024_13", "events_2024", "logs_2024_03", "events_2024_03_07", "events_2024_x3"} {
+		if _, err := p.Parse(key, time.UTC); err == nil || !strings.HasPrefix(err.Error(), fmt.Sprintf("invalid partition key %q: ", key)) {
+			t.Errorf("Parse(%q): unexpected error %v", key, err)
+		}
+	}
+	// a literal Partitioner validates its template, and reports the errors of NewPartitioner
+	literal := Partitioner{Duration: Day, Style: HiveStyle}
+	if key, err := literal.Key(parseIntvl("2024-03-07T00:00:00Z", "2024-03-08T00:00:00Z")); key != "year=2024/month=03/day=07" || err != nil {
+		t.Errorf("Key: unexpected %q, %v for a literal Partitioner", key, err)
+	}
+	literal = Partitioner{Duration: Day, Style: StrftimeStyle, Template: "%Y-%m"}
+	if _, err := literal.Parse("2024-03", time.UTC); err == nil || err.Error() != `template "%Y-%m" does not identify a Day` {
+		t.Errorf("Parse: unexpected error %v for a literal Partitioner", err)
+	}
+	p, _ = NewPartitioner(Month, LayoutStyle, "2006-01-02")
+	if _, err := p.Parse("2024-03-07", time.UTC); err == nil || err.Error() != `invalid partition key "2024-03-07": not on a Month boundary` {
+		t.Errorf("Parse: unexpected error %v", err)
+	}
+}
+
+func ExamplePartitioner_Key() {
+	i := Interval{
+		Start: time.Date(2024, time.March, 6, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2024, time.March, 8, 0, 0, 0, 0, time.UTC),
+	}
+	p, _ := NewPartitioner(Day, HiveStyle, "")
+	days, _ := i.Walk(Day)
+	for day := range days {
+		key, _ := p.Key(day)
+		fmt.Printf("s3://bucket/%s/\n", key)
+	}
+	// Output:
+	// s3://bucket/year=2024/month=03/day=06/
+	// s3://bucket/year=2024/month=03/day=07/
+}
+
+func ExamplePartitioner_Parse() {
+	p, _ := NewPartitioner(Month, StrftimeStyle, "events_%Y_%m")
+	i, _ := p.Parse("events_2024_02", time.UTC)
+	fmt.Println(i)
+	// Output:
+	// [2024-02-01T00:00:00Z, 2024-03-01T00:00:00Z)
+}
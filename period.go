@@ -0,0 +1,351 @@
+package timewalker
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Period represents an ISO 8601 duration such as P1Y2M3DT4H5M6S, or P2W.
+// Like Duration, the date components (Years, Months, Weeks, Days) have calendar semantics, so P1D is not always 24 hours long,
+// whereas the time components (Hours, Minutes, Seconds, Nanoseconds) are exact elapsed time.
+type Period struct {
+	Years       int
+	Months      int
+	Weeks       int
+	Days        int
+	Hours       int
+	Minutes     int
+	Seconds     int
+	Nanoseconds int
+}
+
+// Period returns the ISO 8601 duration equivalent to the receiver, e.g. P1M for Month
+func (d Duration) Period() Period {
+	var p Period
+	switch d {
+	case Day:
+		p.Days = 1
+	case Month:
+		p.Months = 1
+	case Year:
+		p.Years = 1
+	case Week:
+		p.Weeks = 1
+	case Quarter:
+		p.Months = 3
+	}
+	return p
+}
+
+// IsZero reports whether all components of the Period are zero
+func (p Period) IsZero() bool {
+	return p == Period{}
+}
+
+// hasNegative reports whether any component of the Period is negative
+func (p Period) hasNegative() bool {
+	return p.Years < 0 || p.Months < 0 || p.Weeks < 0 || p.Days < 0 ||
+		p.Hours < 0 || p.Minutes < 0 || p.Seconds < 0 || p.Nanoseconds < 0
+}
+
+// Neg returns the Period with all components negated
+func (p Period) Neg() Period {
+	return p.times(-1)
+}
+
+// AddTo returns a new Time by adding the receiver's period to the passed time parameter.
+// The date components are added with time.AddDate, then the time components are added as elapsed time,
+// so that P1D keeps the wall clock across a daylight savings boundary, while PT24H does not.
+func (p Period) AddTo(t time.Time) time.Time {
+	t = t.AddDate(p.Years, p.Months, 7*p.Weeks+p.Days)
+	if p.Hours != 0 || p.Minutes != 0 || p.Seconds != 0 || p.Nanoseconds != 0 {
+		t = t.Add(time.Duration(p.Hours)*time.Hour +
+			time.Duration(p.Minutes)*time.Minute +
+			time.Duration(p.Seconds)*time.Second +
+			time.Duration(p.Nanoseconds))
+	}
+	return t
+}
+
+// times returns the Period with every component multiplied by k
+func (p Period) times(k int) Period {
+	return Period{
+		Years: k * p.Years, Months: k * p.Months, Weeks: k * p.Weeks, Days: k * p.Days,
+		Hours: k * p.Hours, Minutes: k * p.Minutes, Seconds: k * p.Seconds, Nanoseconds: k * p.Nanoseconds,
+	}
+}
+
+// String formats the Period as an ISO 8601 duration, e.g. P1Y2M3DT4H5M6.5S.
+// The zero Period is PT0S, and a Period with only negative components is prefixed with a minus sign, e.g. -P1D.
+// Weeks are only formatted as PnW when no other component is set, otherwise they are folded into the days.
+// Components of opposite signs are first normalized, where that does not change the Period's meaning, e.g. P1Y-1M is P11M;
+// what remains, e.g. P1M-1D, is formatted with a signed component, which ParsePeriod accepts.
+func (p Period) String() string {
+	if p.IsZero() {
+		return "PT0S"
+	}
+	p = p.normalized()
+	if p.Years <= 0 && p.Months <= 0 && p.Weeks <= 0 && p.Days <= 0 &&
+		p.Hours <= 0 && p.Minutes <= 0 && p.Seconds <= 0 && p.Nanoseconds <= 0 {
+		return "-" + p.Neg().String()
+	}
+	if p == (Period{Weeks: p.Weeks}) {
+		return fmt.Sprintf("P%dW", p.Weeks)
+	}
+
+	var sb strings.Builder
+	sb.WriteString("P")
+	write := func(v int, designator string) {
+		if v != 0 {
+			sb.WriteString(strconv.Itoa(v))
+			sb.WriteString(designator)
+		}
+	}
+	write(p.Years, "Y")
+	write(p.Months, "M")
+	write(7*p.Weeks+p.Days, "D")
+	if p.Hours != 0 || p.Minutes != 0 || p.Seconds != 0 || p.Nanoseconds != 0 {
+		sb.WriteString("T")
+		write(p.Hours, "H")
+		write(p.Minutes, "M")
+		if p.Nanoseconds != 0 {
+			// seconds and fraction are formatted together, with a single sign
+			d := time.Duration(p.Seconds)*time.Second + time.Duration(p.Nanoseconds)
+			if d < 0 {
+				sb.WriteString("-")
+				d = -d
+			}
+			sb.WriteString(strconv.FormatInt(int64(d/time.Second), 10))
+			if frac := d % time.Second; frac != 0 {
+				sb.WriteString(".")
+				sb.WriteString(strings.TrimRight(fmt.Sprintf("%09d", frac), "0"))
+			}
+			sb.WriteString("S")
+		} else {
+			write(p.Seconds, "S")
+		}
+	}
+	return sb.String()
+}
+
+// normalized returns the Period with the same meaning, where components of opposite signs are combined, when adding them is exact:
+// years with months, weeks with days, and the time components together.
+func (p Period) normalized() Period {
+	if p.Years*p.Months < 0 {
+		months := 12*p.Years + p.Months
+		p.Years, p.Months = months/12, months%12
+	}
+	if p.Weeks*p.Days < 0 {
+		p.Weeks, p.Days = 0, 7*p.Weeks+p.Days
+	}
+	if !sameSign(p.Hours, p.Minutes, p.Seconds, p.Nanoseconds) {
+		d := time.Duration(p.Hours)*time.Hour + time.Duration(p.Minutes)*time.Minute +
+			time.Duration(p.Seconds)*time.Second + time.Duration(p.Nanoseconds)
+		p.Hours, d = int(d/time.Hour), d%time.Hour
+		p.Minutes, d = int(d/time.Minute), d%time.Minute
+		p.Seconds, p.Nanoseconds = int(d/time.Second), int(d%time.Second)
+	}
+	return p
+}
+
+// sameSign reports whether none of the values have opposite signs
+func sameSign(values ...int) bool {
+	pos, neg := false, false
+	for _, v := range values {
+		pos, neg = pos || v > 0, neg || v < 0
+	}
+	return !(pos && neg)
+}
+
+// ParsePeriod parses an ISO 8601 duration, in the PnYnMnDTnHnMnS or PnW forms, e.g. P1Y2M3DT4H5M6S, PT36H, or P2W.
+// Components must appear in order, at most once, and only the seconds may have a decimal fraction (with '.' or ',').
+// A leading minus sign negates all components, and as an extension, a component may be negative, e.g. P1M-1D, as formatted by String.
+// Errors report the byte offset of the problem.
+func ParsePeriod(s string) (Period, error) {
+	var p Period
+	errorf := func(pos int, format string, args ...interface{}) (Period, error) {
+		return Period{}, fmt.Errorf("invalid ISO 8601 duration %q: %s at position %d", s, fmt.Sprintf(format, args...), pos)
+	}
+
+	pos := 0
+	neg := false
+	if strings.HasPrefix(s, "-") {
+		neg = true
+		pos++
+	}
+	if pos >= len(s) || s[pos] != 'P' {
+		return errorf(pos, "expected 'P'")
+	}
+	pos++
+
+	// designators in the order they must appear; 'W' is exclusive
+	dateDesignators := "YMWD"
+	timeDesignators := "HMS"
+	designators := dateDesignators
+	inTime := false
+	components := 0
+	hasWeeks := false
+	for pos < len(s) {
+		if s[pos] == 'T' {
+			if inTime {
+				return errorf(pos, "unexpected 'T'")
+			}
+			inTime = true
+			designators = timeDesignators
+			pos++
+			if pos >= len(s) {
+				return errorf(pos, "expected time component after 'T'")
+			}
+			continue
+		}
+
+		// number, with an optional sign and fraction
+		signed := pos < len(s) && s[pos] == '-'
+		if signed {
+			pos++
+		}
+		numStart := pos
+		for pos < len(s) && s[pos] >= '0' && s[pos] <= '9' {
+			pos++
+		}
+		if pos == numStart {
+			return errorf(pos, "expected digit")
+		}
+		v, err := strconv.Atoi(s[numStart:pos])
+		if err != nil {
+			return errorf(numStart, "number out of range")
+		}
+		frac, fracStart := 0, -1
+		if pos < len(s) && (s[pos] == '.' || s[pos] == ',') {
+			pos++
+			fracStart = pos
+			for pos < len(s) && s[pos] >= '0' && s[pos] <= '9' {
+				pos++
+			}
+			digits := pos - fracStart
+			if digits == 0 {
+				return errorf(pos, "expected digit")
+			}
+			if digits > 9 {
+				return errorf(fracStart+9, "fraction exceeds nanosecond precision")
+			}
+			frac, _ = strconv.Atoi(s[fracStart:pos] + strings.Repeat("0", 9-digits))
+		}
+		if signed {
+			v, frac = -v, -frac
+		}
+
+		// designator
+		if pos >= len(s) {
+			return errorf(pos, "expected designator")
+		}
+		d := s[pos]
+		idx := strings.IndexByte(designators, d)
+		if idx < 0 {
+			if strings.IndexByte(dateDesignators+timeDesignators, d) >= 0 {
+				return errorf(pos, "designator '%c' out of order", d)
+			}
+			return errorf(pos, "unexpected character '%c'", d)
+		}
+		if fracStart >= 0 && !(inTime && d == 'S') {
+			return errorf(fracStart-1, "fraction only allowed on seconds")
+		}
+		if hasWeeks || (d == 'W' && components > 0) {
+			return errorf(pos, "weeks cannot be combined with other components")
+		}
+		switch {
+		case !inTime && d == 'Y':
+			p.Years = v
+		case !inTime && d == 'M':
+			p.Months = v
+		case !inTime && d == 'W':
+			p.Weeks = v
+			hasWeeks = true
+		case !inTime && d == 'D':
+			p.Days = v
+		case d == 'H':
+			p.Hours = v
+		case d == 'M':
+			p.Minutes = v
+		case d == 'S':
+			p.Seconds = v
+			p.Nanoseconds = frac
+		}
+		components++
+		// only later designators may follow
+		designators = designators[idx+1:]
+		pos++
+		if fracStart >= 0 && pos < len(s) {
+			return errorf(pos, "fraction must be on the last component")
+		}
+	}
+	if components == 0 {
+		return errorf(pos, "expected at least one component")
+	}
+	if neg {
+		p = p.Neg()
+	}
+	return p, nil
+}
+
+// WalkPeriod produces times from a (incl) to b (excl) in steps of the given Period.
+// As a Period has no natural boundary, a is not rounded, and the k-th time is computed as a + k*p.
+// The Period must be positive, with no negative component, e.g. P1M-30D is rejected, as its multiples would not always increase.
+func WalkPeriod(a, b time.Time, p Period) (<-chan time.Time, error) {
+	if err := validateWalkPeriod(a, p); err != nil {
+		return nil, err
+	}
+	ch := make(chan time.Time)
+	go func() {
+		for k, t := 0, a; t.Before(b); k++ {
+			ch <- t
+			t = p.times(k + 1).AddTo(a)
+		}
+		close(ch)
+	}()
+	return ch, nil
+}
+
+// WalkPeriod traverses the receiver's interval in steps of the given Period, see WalkPeriod.
+// The interval is not rounded, and the last produced Interval may extend past End; an unbounded End requires WalkPeriodContext.
+func (i Interval) WalkPeriod(p Period) (<-chan Interval, error) {
+	if i.Bounds&EndUnbounded != 0 {
+		return nil, errWalkForever(i, "WalkPeriodContext")
+	}
+	return i.WalkPeriodContext(context.Background(), p)
+}
+
+// WalkPeriodContext is like Interval.WalkPeriod, but the walk stops, and the channel is closed, when ctx is done.
+// With an unbounded End, the walk only stops then.
+func (i Interval) WalkPeriodContext(ctx context.Context, p Period) (<-chan Interval, error) {
+	if i.Bounds&StartUnbounded != 0 {
+		return nil, fmt.Errorf("cannot walk an Interval with an unbounded Start: %v", i)
+	}
+	if err := validateWalkPeriod(i.Start, p); err != nil {
+		return nil, err
+	}
+	ch := make(chan Interval)
+	go func() {
+		defer close(ch)
+		start := i.Start
+		for k := 1; i.endsAfter(start); k++ {
+			end := p.times(k).AddTo(i.Start)
+			if !sendInterval(ctx, ch, Interval{Start: start, End: end}) {
+				return
+			}
+			start = end
+		}
+	}()
+	return ch, nil
+}
+
+// validateWalkPeriod checks that the multiples of p added to a are increasing, which requires a positive step and no negative component
+func validateWalkPeriod(a time.Time, p Period) error {
+	if p.hasNegative() || !p.AddTo(a).After(a) {
+		return fmt.Errorf("period must be positive: %v", p)
+	}
+	return nil
+}
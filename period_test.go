@@ -0,0 +1,177 @@
+package timewalker
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestParsePeriod(t *testing.T) {
+	var testData = []struct {
+		inp string // input
+		exp Period // expected result
+		str string // expected String(), when different from inp
+	}{
+		{inp: "P1Y2M3DT4H5M6S", exp: Period{Years: 1, Months: 2, Days: 3, Hours: 4, Minutes: 5, Seconds: 6}},
+		{inp: "P1Y", exp: Period{Years: 1}},
+		{inp: "P1M", exp: Period{Months: 1}},
+		{inp: "PT1M", exp: Period{Minutes: 1}},
+		{inp: "P2W", exp: Period{Weeks: 2}},
+		{inp: "PT36H", exp: Period{Hours: 36}},
+		{inp: "P0D", exp: Period{}, str: "PT0S"},
+		{inp: "PT1.5S", exp: Period{Seconds: 1, Nanoseconds: 500000000}},
+		{inp: "PT0,000000001S", exp: Period{Nanoseconds: 1}, str: "PT0.000000001S"},
+		{inp: "-P1DT1H", exp: Period{Days: -1, Hours: -1}},
+		{inp: "-PT0.25S", exp: Period{Nanoseconds: -250000000}},
+		{inp: "P1M-1D", exp: Period{Months: 1, Days: -1}},
+		{inp: "P-1M1DT-0.5S", exp: Period{Months: -1, Days: 1, Nanoseconds: -500000000}},
+		{inp: "P1Y-1M", exp: Period{Years: 1, Months: -1}, str: "P11M"},
+		{inp: "-P-1Y1M", exp: Period{Years: 1, Months: -1}, str: "P11M"},
+		{inp: "P1W", exp: Period{Weeks: 1}},
+		{inp: "PT1H-1S", exp: Period{Hours: 1, Seconds: -1}, str: "PT59M59S"},
+	}
+	for _, tt := range testData {
+		actual, err := ParsePeriod(tt.inp)
+		if err != nil {
+			t.Errorf("ParsePeriod(%q): unexpected error: %v", tt.inp, err)
+			continue
+		}
+		if actual != tt.exp {
+			t.Errorf("ParsePeriod(%q): \nexp: %#v, \nact: %#v", tt.inp, tt.exp, actual)
+		}
+		str := tt.str
+		if str == "" {
+			str = tt.inp
+		}
+		if actual.String() != str {
+			t.Errorf("ParsePeriod(%q).String(): exp: %s act: %s", tt.inp, str, actual)
+		}
+	}
+}
+
+func TestPeriodStringRoundTrip(t *testing.T) {
+	// whatever the signs of its components, a formatted Period parses back to a Period with the same meaning
+	testData := []Period{
+		{Years: 1, Months: -1},
+		{Years: -2, Months: 25},
+		{Weeks: 1, Days: -1},
+		{Months: 1, Days: -1},
+		{Years: 1, Days: -3, Hours: 2},
+		{Hours: 1, Minutes: -1, Nanoseconds: 1},
+		{Days: 1, Seconds: -1, Nanoseconds: 500000000},
+		{Months: -1, Minutes: 30},
+	}
+	from := parseTime("2001-01-31T12:00:00Z")
+	for _, p := range testData {
+		parsed, err := ParsePeriod(p.String())
+		if err != nil {
+			t.Errorf("ParsePeriod(%#v.String()): unexpected error: %v", p, err)
+			continue
+		}
+		if !parsed.AddTo(from).Equal(p.AddTo(from)) {
+			t.Errorf("ParsePeriod(%#v.String()): %v, %v is not %v", p, p, parsed.AddTo(from), p.AddTo(from))
+		}
+	}
+}
+
+func TestParsePeriodErrors(t *testing.T) {
+	var testData = []struct {
+		inp string // input
+		exp string // expected error
+	}{
+		{"", `invalid ISO 8601 duration "": expected 'P' at position 0`},
+		{"1D", `invalid ISO 8601 duration "1D": expected 'P' at position 0`},
+		{"P", `invalid ISO 8601 duration "P": expected at least one component at position 1`},
+		{"PT", `invalid ISO 8601 duration "PT": expected time component after 'T' at position 2`},
+		{"P1", `invalid ISO 8601 duration "P1": expected designator at position 2`},
+		{"P1D2Y", `invalid ISO 8601 duration "P1D2Y": designator 'Y' out of order at position 4`},
+		{"P1H", `invalid ISO 8601 duration "P1H": designator 'H' out of order at position 2`},
+		{"PT1D", `invalid ISO 8601 duration "PT1D": designator 'D' out of order at position 3`},
+		{"P1X", `invalid ISO 8601 duration "P1X": unexpected character 'X' at position 2`},
+		{"P1W2D", `invalid ISO 8601 duration "P1W2D": weeks cannot be combined with other components at position 4`},
+		{"P1Y2W", `invalid ISO 8601 duration "P1Y2W": weeks cannot be combined with other components at position 4`},
+		{"P1.5D", `invalid ISO 8601 duration "P1.5D": fraction only allowed on seconds at position 2`},
+		{"PT1.S", `invalid ISO 8601 duration "PT1.S": expected digit at position 4`},
+		{"PTH", `invalid ISO 8601 duration "PTH": expected digit at position 2`},
+		{"P1DT1HT", `invalid ISO 8601 duration "P1DT1HT": unexpected 'T' at position 6`},
+		{"PT0.0000000001S", `invalid ISO 8601 duration "PT0.0000000001S": fraction exceeds nanosecond precision at position 13`},
+		{"P99999999999999999999Y", `invalid ISO 8601 duration "P99999999999999999999Y": number out of range at position 1`},
+	}
+	for _, tt := range testData {
+		_, err := ParsePeriod(tt.inp)
+		if err == nil || err.Error() != tt.exp {
+			t.Errorf("ParsePeriod(%q): \nexp: %v, \nact: %v", tt.inp, tt.exp, err)
+		}
+	}
+}
+
+func TestDurationPeriod(t *testing.T) {
+	inp := parseTime("2001-01-31T12:45:56Z")
+	for _, d := range []Duration{Day, Month, Year} {
+		if actual, exp := d.Period().AddTo(inp), d.AddTo(inp); actual != exp {
+			t.Errorf("%s.Period().AddTo(%s): exp: %v act: %v", d, inp, exp, actual)
+		}
+	}
+}
+
+func ExamplePeriod_AddTo() {
+	// Spring forward in Montreal
+	loc, _ := time.LoadLocation("America/Montreal")
+	t := time.Date(2008, time.March, 8, 12, 0, 0, 0, loc)
+	for _, s := range []string{"P1D", "PT24H", "P1DT1H"} {
+		p, _ := ParsePeriod(s)
+		fmt.Printf("%v + %v = %v\n", t, p, p.AddTo(t))
+	}
+	// Output:
+	// 2008-03-08 12:00:00 -0500 EST + P1D = 2008-03-09 12:00:00 -0400 EDT
+	// 2008-03-08 12:00:00 -0500 EST + PT24H = 2008-03-09 13:00:00 -0400 EDT
+	// 2008-03-08 12:00:00 -0500 EST + P1DT1H = 2008-03-09 13:00:00 -0400 EDT
+}
+
+func ExampleWalkPeriod() {
+	p, _ := ParsePeriod("PT6H")
+	ch, _ := WalkPeriod(parseTime("2004-02-28T00:00:00Z"), parseTime("2004-02-29T00:00:00Z"), p)
+	for t := range ch {
+		fmt.Printf("%s\n", t)
+	}
+	// Output:
+	// 2004-02-28 00:00:00 +0000 UTC
+	// 2004-02-28 06:00:00 +0000 UTC
+	// 2004-02-28 12:00:00 +0000 UTC
+	// 2004-02-28 18:00:00 +0000 UTC
+}
+
+func ExampleInterval_WalkPeriod() {
+	p, _ := ParsePeriod("P2W")
+	i := parseIntvl("2004-02-02T00:00:00Z", "2004-03-01T00:00:00Z")
+	ch, _ := i.WalkPeriod(p)
+	for w := range ch {
+		fmt.Println(w)
+	}
+	// Output:
+	// [2004-02-02T00:00:00Z, 2004-02-16T00:00:00Z)
+	// [2004-02-16T00:00:00Z, 2004-03-01T00:00:00Z)
+}
+
+func TestWalkPeriodNonPositive(t *testing.T) {
+	a := parseTime("2004-02-28T00:00:00Z")
+	if _, err := WalkPeriod(a, a, Period{}); err == nil {
+		t.Error("Expected error for zero Period")
+	}
+	if _, err := (Interval{Start: a, End: a}).WalkPeriod(Period{Days: -1}); err == nil {
+		t.Error("Expected error for negative Period")
+	}
+	// the first step of P1M-30D is positive, but its multiples are not increasing
+	mixed, _ := ParsePeriod("P1M-30D")
+	i := parseIntvl("2001-01-01T00:00:00Z", "2001-12-31T00:00:00Z")
+	if _, err := WalkPeriod(i.Start, i.End, mixed); err == nil || err.Error() != "period must be positive: P1M-30D" {
+		t.Errorf("WalkPeriod(%v): expected an error for a mixed-sign Period, got %v", mixed, err)
+	}
+	if _, err := i.WalkPeriod(mixed); err == nil {
+		t.Errorf("Interval.WalkPeriod(%v): expected an error for a mixed-sign Period", mixed)
+	}
+	r := RepeatingInterval{Repetitions: 3, Start: i.Start, Period: mixed}
+	if _, err := r.Walk(); err == nil {
+		t.Errorf("RepeatingInterval.Walk(%v): expected an error for a mixed-sign Period", mixed)
+	}
+}
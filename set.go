@@ -0,0 +1,50 @@
+package timewalker
+
+import "time"
+
+// Contains reports whether t is within the receiver's interval, [Start,End) unless its Bounds say otherwise
+func (i Interval) Contains(t time.Time) bool {
+	return i.afterStart(t) && i.beforeEnd(t)
+}
+
+// Overlaps reports whether the receiver and j have at least one instant in common
+func (i Interval) Overlaps(j Interval) bool {
+	return !i.Intersect(j).IsEmpty()
+}
+
+// Intersect returns the interval of instants common to the receiver and j, which is unbounded only on a side where both are,
+// and exclusive on a side where the boundary they share is exclusive in either.
+// When they are disjoint, the result is the empty [Start,Start) interval (see IsEmpty), at the later of the two Starts.
+func (i Interval) Intersect(j Interval) Interval {
+	var r Interval
+	switch {
+	case i.Bounds&StartUnbounded != 0 && j.Bounds&StartUnbounded != 0:
+		r.Bounds |= StartUnbounded
+	case i.Bounds&StartUnbounded != 0, j.Bounds&StartUnbounded == 0 && j.Start.After(i.Start):
+		r.Start = j.Start
+		r.Bounds |= j.Bounds & StartExclusive
+	case j.Bounds&StartUnbounded != 0, i.Start.After(j.Start):
+		r.Start = i.Start
+		r.Bounds |= i.Bounds & StartExclusive
+	default: // same Start
+		r.Start = i.Start
+		r.Bounds |= (i.Bounds | j.Bounds) & StartExclusive
+	}
+	switch {
+	case i.Bounds&EndUnbounded != 0 && j.Bounds&EndUnbounded != 0:
+		r.Bounds |= EndUnbounded
+	case i.Bounds&EndUnbounded != 0, j.Bounds&EndUnbounded == 0 && j.End.Before(i.End):
+		r.End = j.End
+		r.Bounds |= j.Bounds & EndInclusive
+	case j.Bounds&EndUnbounded != 0, i.End.Before(j.End):
+		r.End = i.End
+		r.Bounds |= i.Bounds & EndInclusive
+	default: // same End
+		r.End = i.End
+		r.Bounds |= i.Bounds & j.Bounds & EndInclusive
+	}
+	if r.IsEmpty() {
+		r.End, r.Bounds = r.Start, 0
+	}
+	return r
+}
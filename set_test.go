@@ -0,0 +1,102 @@
+package timewalker
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestIntervalContains(t *testing.T) {
+	i := parseIntvl("2001-01-01T00:00:00Z", "2001-02-01T00:00:00Z")
+	var testData = []struct {
+		bnd Bounds // bounds of i
+		inp string // time to test
+		exp bool   // expected result
+	}{
+		{0, "2001-01-01T00:00:00Z", true},
+		{0, "2001-01-15T00:00:00Z", true},
+		{0, "2001-02-01T00:00:00Z", false},
+		{0, "2000-12-31T23:59:59Z", false},
+		{StartUnbounded, "1900-01-01T00:00:00Z", true},
+		{StartUnbounded, "2001-02-01T00:00:00Z", false},
+		{EndUnbounded, "2100-01-01T00:00:00Z", true},
+		{EndUnbounded, "2000-12-31T23:59:59Z", false},
+		{StartUnbounded | EndUnbounded, "0001-01-01T00:00:00Z", true},
+	}
+	for _, tt := range testData {
+		i.Bounds = tt.bnd
+		if actual := i.Contains(parseTime(tt.inp)); actual != tt.exp {
+			t.Errorf("%v.Contains(%s): exp: %v act: %v", i, tt.inp, tt.exp, actual)
+		}
+	}
+}
+
+func TestIntervalIntersect(t *testing.T) {
+	since := func(a string) Interval { return Interval{Start: parseTime(a), Bounds: EndUnbounded} }
+	until := func(b string) Interval { return Interval{End: parseTime(b), Bounds: StartUnbounded} }
+	all := Interval{Bounds: StartUnbounded | EndUnbounded}
+	var testData = []struct {
+		i, j Interval // operands
+		exp  Interval // expected result
+	}{
+		{ // overlapping
+			i:   parseIntvl("2001-01-01T00:00:00Z", "2001-03-01T00:00:00Z"),
+			j:   parseIntvl("2001-02-01T00:00:00Z", "2001-04-01T00:00:00Z"),
+			exp: parseIntvl("2001-02-01T00:00:00Z", "2001-03-01T00:00:00Z"),
+		}, { // nested
+			i:   parseIntvl("2001-01-01T00:00:00Z", "2001-04-01T00:00:00Z"),
+			j:   parseIntvl("2001-02-01T00:00:00Z", "2001-03-01T00:00:00Z"),
+			exp: parseIntvl("2001-02-01T00:00:00Z", "2001-03-01T00:00:00Z"),
+		}, { // adjacent
+			i:   parseIntvl("2001-01-01T00:00:00Z", "2001-02-01T00:00:00Z"),
+			j:   parseIntvl("2001-02-01T00:00:00Z", "2001-03-01T00:00:00Z"),
+			exp: parseIntvl("2001-02-01T00:00:00Z", "2001-02-01T00:00:00Z"),
+		}, { // disjoint
+			i:   parseIntvl("2001-01-01T00:00:00Z", "2001-02-01T00:00:00Z"),
+			j:   parseIntvl("2001-03-01T00:00:00Z", "2001-04-01T00:00:00Z"),
+			exp: parseIntvl("2001-03-01T00:00:00Z", "2001-03-01T00:00:00Z"),
+		}, { // since and until
+			i:   since("2001-01-01T00:00:00Z"),
+			j:   until("2001-03-01T00:00:00Z"),
+			exp: parseIntvl("2001-01-01T00:00:00Z", "2001-03-01T00:00:00Z"),
+		}, { // since and since
+			i:   since("2001-01-01T00:00:00Z"),
+			j:   since("2001-03-01T00:00:00Z"),
+			exp: since("2001-03-01T00:00:00Z"),
+		}, { // until and until
+			i:   until("2001-01-01T00:00:00Z"),
+			j:   until("2001-03-01T00:00:00Z"),
+			exp: until("2001-01-01T00:00:00Z"),
+		}, { // everything
+			i:   all,
+			j:   parseIntvl("2001-02-01T00:00:00Z", "2001-03-01T00:00:00Z"),
+			exp: parseIntvl("2001-02-01T00:00:00Z", "2001-03-01T00:00:00Z"),
+		}, {
+			i:   all,
+			j:   all,
+			exp: all,
+		},
+	}
+	for _, tt := range testData {
+		for _, ops := range [][2]Interval{{tt.i, tt.j}, {tt.j, tt.i}} {
+			actual := ops[0].Intersect(ops[1])
+			if actual != tt.exp {
+				t.Errorf("%v.Intersect(%v): \nexp: %v, \nact: %v", ops[0], ops[1], tt.exp, actual)
+			}
+			if ops[0].Overlaps(ops[1]) == tt.exp.IsEmpty() {
+				t.Errorf("%v.Overlaps(%v): exp: %v", ops[0], ops[1], !tt.exp.IsEmpty())
+			}
+		}
+	}
+}
+
+func ExampleInterval_Intersect() {
+	subscription := Interval{Start: parseTime("2001-01-15T00:00:00Z"), Bounds: EndUnbounded}
+	maintenance := Interval{End: parseTime("2001-02-01T00:00:00Z"), Bounds: StartUnbounded}
+	fmt.Println(subscription)
+	fmt.Println(maintenance)
+	fmt.Println(subscription.Intersect(maintenance))
+	// Output:
+	// [2001-01-15T00:00:00Z, +∞)
+	// (-∞, 2001-02-01T00:00:00Z)
+	// [2001-01-15T00:00:00Z, 2001-02-01T00:00:00Z)
+}
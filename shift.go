@@ -0,0 +1,169 @@
+package timewalker
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Overflow defines how calendar arithmetic handles a day of the month which does not exist in the target month, e.g. Jan 31 + 1 Month, or Feb 29 + 1 Year
+type Overflow int
+
+// Different package constants defining an enum type for Overflow
+const (
+	// OverflowNormalize carries the excess days into the following month, as time.AddDate does: Jan 31 + 1 Month == Mar 3 (Mar 2 in leap years)
+	OverflowNormalize Overflow = iota
+	// OverflowClamp clamps the day to the last day of the target month: Jan 31 + 1 Month == Feb 28 (Feb 29 in leap years)
+	OverflowClamp
+	// OverflowError returns an error instead: Jan 31 + 1 Month is not defined
+	OverflowError
+)
+
+// Produces Human readable representations of the Overflow enum values
+func (o Overflow) String() string {
+	str := "Invalid"
+	switch o {
+	case OverflowNormalize:
+		str = "Normalize"
+	case OverflowClamp:
+		str = "Clamp"
+	case OverflowError:
+		str = "Error"
+	}
+	return str
+}
+
+// AddN returns a new Time by adding n times the receiver's duration to t, n may be negative. The Overflow policy decides what happens when the resulting day does not exist in the target month
+func (d Duration) AddN(t time.Time, n int, o Overflow) (time.Time, error) {
+	var yr, mo, dy int
+	switch d {
+	case Day:
+		dy = n
+	case Month:
+		mo = n
+	case Year:
+		yr = n
+	case Week:
+		dy = 7 * n
+	case Quarter:
+		mo = 3 * n
+	default:
+		return t, fmt.Errorf("invalid Duration: %v", d)
+	}
+	switch o {
+	case OverflowNormalize:
+		return t.AddDate(yr, mo, dy), nil
+	case OverflowClamp:
+		return addDateClamped(t, yr, mo, dy), nil
+	case OverflowError:
+		clamped := addDateClamped(t, yr, mo, dy)
+		if clamped.Day() != t.Day() && dy == 0 {
+			return t, fmt.Errorf("%s overflows: day %d does not exist in %s %d", t.Format("2006-01-02"), t.Day(), clamped.Month(), clamped.Year())
+		}
+		return clamped, nil
+	}
+	return t, fmt.Errorf("invalid Overflow policy: %v", o)
+}
+
+// AddToWith is like AddTo, but with an explicit Overflow policy; AddTo uses OverflowNormalize
+func (d Duration) AddToWith(t time.Time, o Overflow) (time.Time, error) {
+	return d.AddN(t, 1, o)
+}
+
+// Shift returns the interval equivalent to the receiver, n periods of the given duration away; n may be negative.
+// Start and End are shifted independently, so with OverflowClamp, [2004-02-29, 2004-03-01) shifted by -1 Year is [2003-02-28, 2003-03-01).
+// With OverflowNormalize, both may land on the same day, e.g. [2004-02-29, 2004-03-01) would become [2003-03-01, 2003-03-01),
+// so shifting a non-empty interval to an empty one is an error. Unbounded boundaries stay unbounded.
+func (i Interval) Shift(d Duration, n int, o Overflow) (Interval, error) {
+	start, err := d.AddN(i.Start, n, o)
+	if err != nil {
+		return i, err
+	}
+	end, err := d.AddN(i.End, n, o)
+	if err != nil {
+		return i, err
+	}
+	if i.Bounds&(StartUnbounded|EndUnbounded) == 0 && i.End.After(i.Start) && !end.After(start) {
+		return i, fmt.Errorf("%v shifted by %d %v is empty: [%s, %s)", i, n, d, start.Format(time.RFC3339), end.Format(time.RFC3339))
+	}
+	i.Start, i.End = start, end
+	return i, nil
+}
+
+// WalkAnchored produces times from a (incl) to b (excl), in steps of the given duration, all anchored on a.
+// Unlike Walk, a is not rounded to a Duration boundary, and the k-th time is computed as a + k*d, so with OverflowClamp
+// the day of month is sticky: Jan 31, Feb 28, Mar 31, Apr 30,...
+// OverflowError is not accepted, as the error could only be detected while walking.
+func WalkAnchored(a, b time.Time, d Duration, o Overflow) (<-chan time.Time, error) {
+	if err := validateAnchored(a, d, o); err != nil {
+		return nil, err
+	}
+	ch := make(chan time.Time)
+	go func() {
+		for k, t := 0, a; t.Before(b); k++ {
+			ch <- t
+			t, _ = d.AddN(a, k+1, o)
+		}
+		close(ch)
+	}()
+	return ch, nil
+}
+
+// WalkAnchored traverses the receiver's interval in steps of the given duration, anchored on Start, see WalkAnchored.
+// The interval is not rounded, and the last produced Interval may extend past End; an unbounded End requires WalkAnchoredContext.
+func (i Interval) WalkAnchored(d Duration, o Overflow) (<-chan Interval, error) {
+	if i.Bounds&EndUnbounded != 0 {
+		return nil, errWalkForever(i, "WalkAnchoredContext")
+	}
+	return i.WalkAnchoredContext(context.Background(), d, o)
+}
+
+// WalkAnchoredContext is like Interval.WalkAnchored, but the walk stops, and the channel is closed, when ctx is done.
+// With an unbounded End, the walk only stops then.
+func (i Interval) WalkAnchoredContext(ctx context.Context, d Duration, o Overflow) (<-chan Interval, error) {
+	if i.Bounds&StartUnbounded != 0 {
+		return nil, fmt.Errorf("cannot walk an Interval with an unbounded Start: %v", i)
+	}
+	if err := validateAnchored(i.Start, d, o); err != nil {
+		return nil, err
+	}
+	ch := make(chan Interval)
+	go func() {
+		defer close(ch)
+		start := i.Start
+		for k := 1; i.endsAfter(start); k++ {
+			end, _ := d.AddN(i.Start, k, o)
+			if !sendInterval(ctx, ch, Interval{Start: start, End: end}) {
+				return
+			}
+			start = end
+		}
+	}()
+	return ch, nil
+}
+
+// validateAnchored checks the arguments of the anchored walkers, so the walking goroutine can ignore errors
+func validateAnchored(a time.Time, d Duration, o Overflow) error {
+	if o == OverflowError {
+		return fmt.Errorf("invalid Overflow policy for walking: %v", o)
+	}
+	_, err := d.AddN(a, 1, o)
+	return err
+}
+
+// addDateClamped is like t.AddDate(yr, mo, dy), but the day of month is clamped to the length of the target month before the days are added
+func addDateClamped(t time.Time, yr, mo, dy int) time.Time {
+	year, month, day := t.Date()
+	hour, min, sec := t.Clock()
+	// normalize the target year and month first, day 1 always exists
+	target := time.Date(year+yr, month+time.Month(mo), 1, 0, 0, 0, 0, time.UTC)
+	if last := daysIn(target.Year(), target.Month()); day > last {
+		day = last
+	}
+	return time.Date(target.Year(), target.Month(), day+dy, hour, min, sec, t.Nanosecond(), t.Location())
+}
+
+// daysIn returns the number of days in the given month
+func daysIn(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
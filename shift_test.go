@@ -0,0 +1,191 @@
+package timewalker
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestDurationAddN(t *testing.T) {
+	var testData = []struct {
+		inp time.Time // input
+		dur Duration  // duration
+		n   int       // multiple
+		ovf Overflow  // overflow policy
+		exp time.Time // expected result
+	}{
+		{ // Month, Normalize: like time.AddDate
+			inp: parseTime("2001-01-31T12:45:56Z"),
+			dur: Month, n: 1, ovf: OverflowNormalize,
+			exp: parseTime("2001-03-03T12:45:56Z"),
+		}, { // Month, Clamp
+			inp: parseTime("2001-01-31T12:45:56Z"),
+			dur: Month, n: 1, ovf: OverflowClamp,
+			exp: parseTime("2001-02-28T12:45:56Z"),
+		}, { // Month, Clamp, leap year
+			inp: parseTime("2004-01-31T12:45:56Z"),
+			dur: Month, n: 1, ovf: OverflowClamp,
+			exp: parseTime("2004-02-29T12:45:56Z"),
+		}, { // Month, Clamp, backwards
+			inp: parseTime("2001-03-31T00:00:00Z"),
+			dur: Month, n: -1, ovf: OverflowClamp,
+			exp: parseTime("2001-02-28T00:00:00Z"),
+		}, { // Month, Clamp, across year boundary
+			inp: parseTime("2001-12-31T00:00:00Z"),
+			dur: Month, n: 2, ovf: OverflowClamp,
+			exp: parseTime("2002-02-28T00:00:00Z"),
+		}, { // Month, Clamp, no overflow
+			inp: parseTime("2001-01-15T00:00:00Z"),
+			dur: Month, n: 13, ovf: OverflowClamp,
+			exp: parseTime("2002-02-15T00:00:00Z"),
+		}, { // Year, Normalize from Feb 29
+			inp: parseTime("2004-02-29T00:00:00Z"),
+			dur: Year, n: 1, ovf: OverflowNormalize,
+			exp: parseTime("2005-03-01T00:00:00Z"),
+		}, { // Year, Clamp from Feb 29
+			inp: parseTime("2004-02-29T00:00:00Z"),
+			dur: Year, n: -1, ovf: OverflowClamp,
+			exp: parseTime("2003-02-28T00:00:00Z"),
+		}, { // Day, policy does not matter
+			inp: parseTime("2004-02-28T00:00:00Z"),
+			dur: Day, n: 2, ovf: OverflowClamp,
+			exp: parseTime("2004-03-01T00:00:00Z"),
+		},
+	}
+	for _, tt := range testData {
+		actual, err := tt.dur.AddN(tt.inp, tt.n, tt.ovf)
+		if err != nil {
+			t.Errorf("%s.AddN(%s,%d,%s): unexpected error: %v", tt.dur, tt.inp, tt.n, tt.ovf, err)
+		}
+		if actual != tt.exp {
+			t.Errorf("%s.AddN(%s,%d,%s): \nexp: %v, \nact: %v", tt.dur, tt.inp, tt.n, tt.ovf, tt.exp, actual)
+		}
+	}
+}
+
+func TestDurationAddNInvalid(t *testing.T) {
+	if _, err := Duration(42).AddN(parseTime("2001-02-03T00:00:00Z"), 1, OverflowClamp); err == nil {
+		t.Error("Expected error for invalid Duration")
+	}
+	if _, err := Month.AddN(parseTime("2001-02-03T00:00:00Z"), 1, Overflow(42)); err == nil {
+		t.Error("Expected error for invalid Overflow")
+	}
+}
+
+func TestDurationAddNInLocation(t *testing.T) {
+	// clamping keeps the wall clock, across a DST boundary
+	loc, _ := time.LoadLocation("America/Montreal")
+	inp := time.Date(2001, time.March, 31, 9, 0, 0, 0, loc)
+	exp := time.Date(2001, time.April, 30, 9, 0, 0, 0, loc)
+	actual, _ := Month.AddN(inp, 1, OverflowClamp)
+	if !actual.Equal(exp) {
+		t.Errorf("Month.AddN(%s,1,Clamp): \nexp: %v, \nact: %v", inp, exp, actual)
+	}
+}
+
+func ExampleInterval_Shift() {
+	// previous month, for a month-over-month comparison
+	i := parseIntvl("2001-03-01T00:00:00Z", "2001-04-01T00:00:00Z")
+	prev, _ := i.Shift(Month, -1, OverflowClamp)
+	fmt.Println(prev)
+
+	// same day last year, for a year-over-year comparison
+	i = parseIntvl("2004-02-29T00:00:00Z", "2004-03-01T00:00:00Z")
+	clamped, _ := i.Shift(Year, -1, OverflowClamp)
+	_, err := i.Shift(Year, -1, OverflowNormalize)
+	fmt.Println(clamped)
+	fmt.Println(err)
+
+	// Output:
+	// [2001-02-01T00:00:00Z, 2001-03-01T00:00:00Z)
+	// [2003-02-28T00:00:00Z, 2003-03-01T00:00:00Z)
+	// [2004-02-29T00:00:00Z, 2004-03-01T00:00:00Z) shifted by -1 Year is empty: [2003-03-01T00:00:00Z, 2003-03-01T00:00:00Z)
+}
+
+func TestShift(t *testing.T) {
+	var testData = []struct {
+		inp Interval // input
+		dur Duration // duration
+		n   int      // multiple
+		ovf Overflow // overflow policy
+		exp string   // expected result, or error
+	}{
+		{parseIntvl("2004-02-29T00:00:00Z", "2004-03-01T00:00:00Z"), Year, 1, OverflowClamp, "[2005-02-28T00:00:00Z, 2005-03-01T00:00:00Z)"},
+		{parseIntvl("2004-02-29T00:00:00Z", "2004-03-01T00:00:00Z"), Year, 4, OverflowNormalize, "[2008-02-29T00:00:00Z, 2008-03-01T00:00:00Z)"},
+		{parseIntvl("2004-02-29T00:00:00Z", "2004-03-01T00:00:00Z"), Year, 1, OverflowNormalize, "[2004-02-29T00:00:00Z, 2004-03-01T00:00:00Z) shifted by 1 Year is empty: [2005-03-01T00:00:00Z, 2005-03-01T00:00:00Z)"},
+		{parseIntvl("2004-02-29T00:00:00Z", "2004-03-01T00:00:00Z"), Year, 1, OverflowError, "2004-02-29 overflows: day 29 does not exist in February 2005"},
+		{parseIntvl("2001-01-31T00:00:00Z", "2001-02-01T00:00:00Z"), Month, 1, OverflowNormalize, "[2001-01-31T00:00:00Z, 2001-02-01T00:00:00Z) shifted by 1 Month is empty: [2001-03-03T00:00:00Z, 2001-03-01T00:00:00Z)"},
+		{Interval{Start: parseTime("2004-02-29T00:00:00Z"), Bounds: EndUnbounded}, Year, 1, OverflowNormalize, "[2005-03-01T00:00:00Z, +∞)"},
+	}
+	for _, tt := range testData {
+		actual, err := tt.inp.Shift(tt.dur, tt.n, tt.ovf)
+		act := actual.String()
+		if err != nil {
+			act = err.Error()
+		}
+		if act != tt.exp {
+			t.Errorf("%v.Shift(%s,%d,%s): \nexp: %v, \nact: %v", tt.inp, tt.dur, tt.n, tt.ovf, tt.exp, act)
+		}
+	}
+}
+
+func TestDurationAddToWithError(t *testing.T) {
+	var testData = []struct {
+		inp time.Time // input
+		dur Duration  // duration
+		err bool      // expect an error
+	}{
+		{inp: parseTime("2001-01-31T00:00:00Z"), dur: Month, err: true},
+		{inp: parseTime("2001-01-28T00:00:00Z"), dur: Month, err: false},
+		{inp: parseTime("2004-02-29T00:00:00Z"), dur: Year, err: true},
+		{inp: parseTime("2004-01-31T00:00:00Z"), dur: Day, err: false},
+	}
+	for _, tt := range testData {
+		actual, err := tt.dur.AddToWith(tt.inp, OverflowError)
+		if (err != nil) != tt.err {
+			t.Errorf("%s.AddToWith(%s,Error): unexpected error value: %v", tt.dur, tt.inp, err)
+		}
+		if err == nil && actual != tt.dur.AddTo(tt.inp) {
+			t.Errorf("%s.AddToWith(%s,Error): \nexp: %v, \nact: %v", tt.dur, tt.inp, tt.dur.AddTo(tt.inp), actual)
+		}
+	}
+}
+
+func TestWalkAnchoredErrorPolicy(t *testing.T) {
+	a := parseTime("2001-01-31T00:00:00Z")
+	if _, err := WalkAnchored(a, a, Month, OverflowError); err == nil {
+		t.Error("Expected error for OverflowError policy")
+	}
+	if _, err := (Interval{Start: a, End: a}).WalkAnchored(Duration(42), OverflowClamp); err == nil {
+		t.Error("Expected error for invalid Duration")
+	}
+}
+
+func ExampleWalkAnchored() {
+	// billing anniversaries on the 31st
+	a := parseTime("2004-01-31T00:00:00Z")
+	b := parseTime("2004-06-01T00:00:00Z")
+	for _, o := range []Overflow{OverflowClamp, OverflowNormalize} {
+		fmt.Printf("%s:", o)
+		ch, _ := WalkAnchored(a, b, Month, o)
+		for t := range ch {
+			fmt.Printf(" %s", t.Format("Jan 02"))
+		}
+		fmt.Println()
+	}
+	// Output:
+	// Clamp: Jan 31 Feb 29 Mar 31 Apr 30 May 31
+	// Normalize: Jan 31 Mar 02 Mar 31 May 01 May 31
+}
+
+func ExampleInterval_WalkAnchored() {
+	i := parseIntvl("2001-01-31T00:00:00Z", "2001-04-01T00:00:00Z")
+	ch, _ := i.WalkAnchored(Month, OverflowClamp)
+	for p := range ch {
+		fmt.Println(p)
+	}
+	// Output:
+	// [2001-01-31T00:00:00Z, 2001-02-28T00:00:00Z)
+	// [2001-02-28T00:00:00Z, 2001-03-31T00:00:00Z)
+	// [2001-03-31T00:00:00Z, 2001-04-30T00:00:00Z)
+}
@@ -0,0 +1,163 @@
+package timewalker
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Value implements driver.Valuer, writing the receiver as a PostgreSQL range literal, suitable for a tstzrange column,
+// e.g. [2001-01-01 00:00:00+00,2001-02-01 00:00:00+00), (,2001-02-01 00:00:00+00), [2001-01-01 00:00:00+00,2001-01-31 00:00:00+00] or empty.
+// To store an Interval as two timestamp columns instead, pass Start and End as separate arguments.
+func (i Interval) Value() (driver.Value, error) {
+	if i.IsEmpty() {
+		return "empty", nil
+	}
+	left, right := i.brackets()
+	var sb strings.Builder
+	if i.Bounds&StartUnbounded != 0 {
+		sb.WriteString("(")
+	} else {
+		sb.WriteString(left)
+		sb.WriteString(formatRangeTime(i.Start))
+	}
+	sb.WriteString(",")
+	if i.Bounds&EndUnbounded != 0 {
+		sb.WriteString(")")
+	} else {
+		sb.WriteString(formatRangeTime(i.End))
+		sb.WriteString(right)
+	}
+	return sb.String(), nil
+}
+
+// Scan implements sql.Scanner, reading a PostgreSQL range literal as written by Value, including quoted, exclusive and inclusive bounds,
+// infinite bounds, and the empty range which is scanned as the zero Interval, as is NULL: to tell them apart, select the column IS NULL too.
+// Times are scanned in UTC, or in a fixed zone with the literal's offset, whatever the Location of the host.
+// To scan an Interval from two timestamp columns instead, scan into &i.Start and &i.End.
+func (i *Interval) Scan(src interface{}) error {
+	var s string
+	switch v := src.(type) {
+	case nil:
+		*i = Interval{}
+		return nil
+	case string:
+		s = v
+	case []byte:
+		s = string(v)
+	default:
+		return fmt.Errorf("cannot scan %T into Interval", src)
+	}
+	parsed, err := parseRange(s)
+	if err != nil {
+		return err
+	}
+	*i = parsed
+	return nil
+}
+
+// parseRange parses a PostgreSQL range literal of timestamps
+func parseRange(s string) (Interval, error) {
+	lit := strings.TrimSpace(s)
+	if strings.EqualFold(lit, "empty") {
+		return Interval{}, nil
+	}
+	if len(lit) < 3 || (lit[0] != '[' && lit[0] != '(') || (lit[len(lit)-1] != ')' && lit[len(lit)-1] != ']') {
+		return Interval{}, fmt.Errorf("invalid range literal %q", s)
+	}
+	lower, upper, ok := splitRange(lit[1 : len(lit)-1])
+	if !ok {
+		return Interval{}, fmt.Errorf("invalid range literal %q: expected two bounds separated by ','", s)
+	}
+
+	var i Interval
+	if lower == "" || lower == "-infinity" {
+		i.Bounds |= StartUnbounded
+	} else {
+		if lit[0] == '(' {
+			i.Bounds |= StartExclusive
+		}
+		t, err := parseRangeTime(lower)
+		if err != nil {
+			return Interval{}, fmt.Errorf("invalid range literal %q: %v", s, err)
+		}
+		i.Start = t
+	}
+	if upper == "" || upper == "infinity" {
+		i.Bounds |= EndUnbounded
+	} else {
+		if lit[len(lit)-1] == ']' {
+			i.Bounds |= EndInclusive
+		}
+		t, err := parseRangeTime(upper)
+		if err != nil {
+			return Interval{}, fmt.Errorf("invalid range literal %q: %v", s, err)
+		}
+		i.End = t
+	}
+	return i, nil
+}
+
+// splitRange splits the inside of a range literal into its (unquoted) lower and upper bounds
+func splitRange(s string) (lower, upper string, ok bool) {
+	var bounds []string
+	var sb strings.Builder
+	quoted := false
+	for pos := 0; pos < len(s); pos++ {
+		c := s[pos]
+		switch {
+		case c == '"' && quoted && pos+1 < len(s) && s[pos+1] == '"':
+			sb.WriteByte('"')
+			pos++
+		case c == '"':
+			quoted = !quoted
+		case c == '\\' && pos+1 < len(s):
+			sb.WriteByte(s[pos+1])
+			pos++
+		case c == ',' && !quoted:
+			bounds = append(bounds, sb.String())
+			sb.Reset()
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	bounds = append(bounds, sb.String())
+	if quoted || len(bounds) != 2 {
+		return "", "", false
+	}
+	return bounds[0], bounds[1], true
+}
+
+// rangeTimeLayouts are the timestamptz output formats of PostgreSQL, for all offset precisions
+var rangeTimeLayouts = []string{
+	"2006-01-02 15:04:05.999999999-07",
+	"2006-01-02 15:04:05.999999999-07:00",
+	"2006-01-02 15:04:05.999999999-07:00:00",
+}
+
+// parseRangeTime parses a timestamp bound of a range literal, in UTC for a zero offset, or else in a fixed zone.
+// Unlike time.Parse, the result does not depend on the host's Local zone.
+func parseRangeTime(s string) (time.Time, error) {
+	var err error
+	for _, layout := range rangeTimeLayouts {
+		var t time.Time
+		if t, err = time.ParseInLocation(layout, s, time.UTC); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, err
+}
+
+// formatRangeTime formats a timestamp bound of a range literal, as PostgreSQL does: offsets are shortened to hours when possible,
+// and include seconds when needed, e.g. -05:17:32 for the local mean time of America/Montreal
+func formatRangeTime(t time.Time) string {
+	layout := "2006-01-02 15:04:05.999999999-07:00"
+	switch _, offset := t.Zone(); {
+	case offset%3600 == 0:
+		layout = "2006-01-02 15:04:05.999999999-07"
+	case offset%60 != 0:
+		layout = "2006-01-02 15:04:05.999999999-07:00:00"
+	}
+	return t.Format(layout)
+}
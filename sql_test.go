@@ -0,0 +1,140 @@
+package timewalker
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+	"time"
+)
+
+// compile time checks
+var (
+	_ sql.Scanner   = (*Interval)(nil)
+	_ driver.Valuer = Interval{}
+)
+
+func TestIntervalScan(t *testing.T) {
+	var testData = []struct {
+		inp string   // range literal
+		exp Interval // expected result
+		val string   // expected Value(), when different from inp
+	}{
+		{
+			inp: "[2001-01-01 00:00:00+00,2001-02-01 00:00:00+00)",
+			exp: parseIntvl("2001-01-01T00:00:00Z", "2001-02-01T00:00:00Z"),
+		}, { // as output by PostgreSQL, with quotes and session offsets
+			inp: `["2001-01-01 00:00:00-05","2001-02-01 00:00:00.123456+05:30")`,
+			exp: parseIntvl("2001-01-01T05:00:00Z", "2001-01-31T18:30:00.123456Z"),
+			val: "[2001-01-01 00:00:00-05,2001-02-01 00:00:00.123456+05:30)",
+		}, {
+			inp: "(,2001-02-01 00:00:00+00)",
+			exp: Interval{End: parseTime("2001-02-01T00:00:00Z"), Bounds: StartUnbounded},
+		}, {
+			inp: "[2001-01-01 00:00:00+00,)",
+			exp: Interval{Start: parseTime("2001-01-01T00:00:00Z"), Bounds: EndUnbounded},
+		}, {
+			inp: "[-infinity,infinity)",
+			exp: Interval{Bounds: StartUnbounded | EndUnbounded},
+			val: "(,)",
+		}, {
+			inp: "(2001-01-01 00:00:00+00,2001-02-01 00:00:00+00]",
+			exp: Interval{Start: parseTime("2001-01-01T00:00:00Z"), End: parseTime("2001-02-01T00:00:00Z"), Bounds: StartExclusive | EndInclusive},
+		}, {
+			inp: "[2001-01-01 00:00:00+00,2001-01-01 00:00:00+00]",
+			exp: Interval{Start: parseTime("2001-01-01T00:00:00Z"), End: parseTime("2001-01-01T00:00:00Z"), Bounds: Closed},
+		}, {
+			inp: "empty",
+			exp: Interval{},
+		}, {
+			inp: " EMPTY ",
+			exp: Interval{},
+			val: "empty",
+		},
+	}
+	for _, tt := range testData {
+		var actual Interval
+		if err := actual.Scan([]byte(tt.inp)); err != nil {
+			t.Errorf("Scan(%q): unexpected error: %v", tt.inp, err)
+			continue
+		}
+		if !actual.Start.Equal(tt.exp.Start) || !actual.End.Equal(tt.exp.End) || actual.Bounds != tt.exp.Bounds {
+			t.Errorf("Scan(%q): \nexp: %v, \nact: %v", tt.inp, tt.exp, actual)
+		}
+		val := tt.val
+		if val == "" {
+			val = tt.inp
+		}
+		if v, err := actual.Value(); err != nil || v != val {
+			t.Errorf("Scan(%q).Value(): \nexp: %v, \nact: %v, %v", tt.inp, val, v, err)
+		}
+	}
+}
+
+func TestIntervalScanErrors(t *testing.T) {
+	var testData = []interface{}{
+		42,
+		"",
+		"[2001-01-01 00:00:00+00)",
+		"[2001-01-01 00:00:00+00,2001-02-01 00:00:00+00,2001-03-01 00:00:00+00)",
+		`["2001-01-01 00:00:00+00,2001-02-01 00:00:00+00)`,
+		"[2001-01-01,2001-02-01)",
+	}
+	for _, inp := range testData {
+		var i Interval
+		if err := i.Scan(inp); err == nil {
+			t.Errorf("Scan(%#v): expected an error", inp)
+		}
+	}
+}
+
+func TestIntervalScanNull(t *testing.T) {
+	i := parseIntvl("2001-01-01T00:00:00Z", "2001-02-01T00:00:00Z")
+	if err := i.Scan(nil); err != nil || i != (Interval{}) {
+		t.Errorf("Scan(nil): \nexp: the zero Interval, \nact: %v, %v", i, err)
+	}
+}
+
+func TestIntervalScanHostZone(t *testing.T) {
+	// the Location of scanned times does not depend on the host's zone
+	defer func(local *time.Location) { time.Local = local }(time.Local)
+	montreal, _ := time.LoadLocation("America/Montreal")
+	const inp = "[2001-01-01 00:00:00+00,2001-01-01 00:00:00-05)"
+	for _, local := range []*time.Location{time.UTC, montreal, time.FixedZone("", 0)} {
+		time.Local = local
+		var i Interval
+		if err := i.Scan(inp); err != nil {
+			t.Fatalf("Scan(%q) with Local %v: unexpected error: %v", inp, local, err)
+		}
+		_, offset := i.End.Zone()
+		if i.Start.Location() != time.UTC || i.End.Location().String() != "" || offset != -5*3600 {
+			t.Errorf("Scan(%q) with Local %v: unexpected Locations %v and %v", inp, local, i.Start.Location(), i.End.Location())
+		}
+	}
+}
+
+func TestIntervalValueInLocation(t *testing.T) {
+	loc, _ := time.LoadLocation("America/Montreal")
+	i := Interval{
+		Start: time.Date(2001, time.January, 1, 0, 0, 0, 0, loc),
+		End:   time.Date(2001, time.July, 1, 0, 0, 0, 0, loc),
+	}
+	exp := "[2001-01-01 00:00:00-05,2001-07-01 00:00:00-04)"
+	if v, _ := i.Value(); v != exp {
+		t.Errorf("Value(): \nexp: %v, \nact: %v", exp, v)
+	}
+
+	// local mean time, before standard time, has an offset with seconds
+	lmt := Interval{
+		Start: time.Date(1880, time.January, 1, 0, 0, 0, 0, loc),
+		End:   time.Date(1880, time.January, 2, 0, 0, 0, 0, loc),
+	}
+	exp = "[1880-01-01 00:00:00-05:17:32,1880-01-02 00:00:00-05:17:32)"
+	v, err := lmt.Value()
+	if v != exp || err != nil {
+		t.Errorf("Value(): \nexp: %v, \nact: %v, %v", exp, v, err)
+	}
+	var scanned Interval
+	if err := scanned.Scan(v); err != nil || !scanned.Start.Equal(lmt.Start) || !scanned.End.Equal(lmt.End) {
+		t.Errorf("Scan(%v): round trip: %v, %v", v, scanned, err)
+	}
+}
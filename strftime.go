@@ -0,0 +1,456 @@
+package timewalker
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Strftime formats t with strftime conversions, e.g. %Y-%m-%d for 2006-01-02, in t's Location. The supported conversions are
+//
+//	%Y year        %C century     %y year % 100   %G ISO 8601 week-based year   %V ISO 8601 week 01-53
+//	%m month 01-12 %d day 01-31   %e day, space padded                          %j day of the year 001-366   %q quarter 1-4
+//	%H hour 00-23  %I hour 01-12  %p AM or PM     %M minute 00-59               %S second 00-59
+//	%a Mon         %A Monday      %b, %h Jan      %B January                    %u weekday 1-7, Monday is 1   %w weekday 0-6, Sunday is 0
+//	%Z zone abbreviation, e.g. EST or -03         %z offset, e.g. -0500         %s seconds since the Unix epoch
+//	%F %Y-%m-%d    %T %H:%M:%S    %D %m/%d/%y     %R %H:%M                      %n newline  %t tab  %% a percent sign
+//
+// Names are in English, and any other conversion is an error.
+func Strftime(t time.Time, format string) (string, error) {
+	f, err := parseStrftime(format)
+	if err != nil {
+		return "", err
+	}
+	return f.format(t), nil
+}
+
+// Strptime parses value with the strftime conversions of format, see Strftime. Numbers may omit their leading zeros, and names are case-insensitive.
+//
+// Fields absent from the format default to January 1st of year 0, at 00:00:00, as with time.Parse. The date is given either by the year, month and day,
+// by the year and day of the year (%j), or by the ISO 8601 week-based year and week (%G and %V), with an optional weekday, Monday by default.
+// A quarter (%q) without a month stands for its first month, and must otherwise hold the month.
+// A weekday given with a year, month and day must match them. A year given by %y alone is in 1969-2068, as with POSIX.
+//
+// The result is in loc, or UTC when loc is nil. An offset (%z) which is not the offset of loc at that instant gives a time in a fixed zone, or in UTC for Z,
+// and a zone abbreviation (%Z), other than UTC and GMT, must be used by loc at that time, which also resolves ambiguous wall clock times.
+// Without either, the wall clock is resolved in loc as with time.Date.
+func Strptime(format, value string, loc *time.Location) (time.Time, error) {
+	f, err := parseStrftime(format)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if loc == nil {
+		loc = time.UTC
+	}
+	t, err := f.parse(value, loc)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time %q for strftime format %q: %v", value, format, err)
+	}
+	return t, nil
+}
+
+// strftimeFormat is a parsed strftime format: a sequence of literal text and conversions
+type strftimeFormat []strftimeItem
+
+type strftimeItem struct {
+	literal string
+	verb    byte // 0 for literal text
+}
+
+// strftimeComposites are the conversions standing for a sequence of conversions
+var strftimeComposites = map[byte]string{'F': "%Y-%m-%d", 'T': "%H:%M:%S", 'D': "%m/%d/%y", 'R': "%H:%M"}
+
+// strftimeWidths are the maximum widths of the numeric conversions
+var strftimeWidths = map[byte]int{
+	'Y': 4, 'C': 2, 'y': 2, 'G': 4, 'V': 2, 'm': 2, 'd': 2, 'e': 2, 'j': 3, 'q': 1,
+	'H': 2, 'I': 2, 'M': 2, 'S': 2, 'u': 1, 'w': 1, 's': 20,
+}
+
+// strftimeNames are the conversions to names, and other non-numeric text
+const strftimeNames = "pabhABZz"
+
+func parseStrftime(format string) (strftimeFormat, error) {
+	var f strftimeFormat
+	var literal strings.Builder
+	flush := func() {
+		if literal.Len() > 0 {
+			f = append(f, strftimeItem{literal: literal.String()})
+			literal.Reset()
+		}
+	}
+	for k := 0; k < len(format); k++ {
+		if format[k] != '%' {
+			literal.WriteByte(format[k])
+			continue
+		}
+		if k+1 == len(format) {
+			return nil, fmt.Errorf("invalid strftime format %q: trailing %% at position %d", format, k)
+		}
+		k++
+		verb := format[k]
+		switch {
+		case verb == '%':
+			literal.WriteByte('%')
+		case verb == 'n':
+			literal.WriteByte('\n')
+		case verb == 't':
+			literal.WriteByte('\t')
+		case strftimeComposites[verb] != "":
+			composite, _ := parseStrftime(strftimeComposites[verb])
+			flush()
+			f = append(f, composite...)
+		case strftimeWidths[verb] > 0 || strings.IndexByte(strftimeNames, verb) >= 0:
+			flush()
+			f = append(f, strftimeItem{verb: verb})
+		default:
+			return nil, fmt.Errorf("invalid strftime format %q: unsupported conversion %%%c at position %d", format, verb, k-1)
+		}
+	}
+	flush()
+	return f, nil
+}
+
+func (f strftimeFormat) format(t time.Time) string {
+	var b strings.Builder
+	for _, item := range f {
+		switch item.verb {
+		case 0:
+			b.WriteString(item.literal)
+		case 'Y':
+			fmt.Fprintf(&b, "%04d", t.Year())
+		case 'C':
+			fmt.Fprintf(&b, "%02d", t.Year()/100)
+		case 'y':
+			fmt.Fprintf(&b, "%02d", t.Year()%100)
+		case 'G':
+			year, _ := t.ISOWeek()
+			fmt.Fprintf(&b, "%04d", year)
+		case 'V':
+			_, week := t.ISOWeek()
+			fmt.Fprintf(&b, "%02d", week)
+		case 'm':
+			fmt.Fprintf(&b, "%02d", int(t.Month()))
+		case 'q':
+			fmt.Fprintf(&b, "%d", (int(t.Month())-1)/3+1)
+		case 'd':
+			fmt.Fprintf(&b, "%02d", t.Day())
+		case 'e':
+			fmt.Fprintf(&b, "%2d", t.Day())
+		case 'j':
+			fmt.Fprintf(&b, "%03d", t.YearDay())
+		case 'H':
+			fmt.Fprintf(&b, "%02d", t.Hour())
+		case 'I':
+			fmt.Fprintf(&b, "%02d", (t.Hour()+11)%12+1)
+		case 'p':
+			b.WriteString(t.Format("PM"))
+		case 'M':
+			fmt.Fprintf(&b, "%02d", t.Minute())
+		case 'S':
+			fmt.Fprintf(&b, "%02d", t.Second())
+		case 'a':
+			b.WriteString(t.Format("Mon"))
+		case 'A':
+			b.WriteString(t.Format("Monday"))
+		case 'b', 'h':
+			b.WriteString(t.Format("Jan"))
+		case 'B':
+			b.WriteString(t.Format("January"))
+		case 'u':
+			fmt.Fprintf(&b, "%d", (int(t.Weekday())+6)%7+1)
+		case 'w':
+			fmt.Fprintf(&b, "%d", int(t.Weekday()))
+		case 'Z':
+			name, _ := t.Zone()
+			b.WriteString(name)
+		case 'z':
+			b.WriteString(t.Format("-0700"))
+		case 's':
+			fmt.Fprintf(&b, "%d", t.Unix())
+		}
+	}
+	return b.String()
+}
+
+// strptimeParser holds the fields parsed by strftimeFormat.parse
+type strptimeParser struct {
+	value  string
+	pos    int
+	fields map[byte]int
+	zone   string
+}
+
+func (p *strptimeParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("%s at position %d", fmt.Sprintf(format, args...), p.pos)
+}
+
+// number parses up to width digits, or a signed number for %s
+func (p *strptimeParser) number(verb byte) (int, error) {
+	start := p.pos
+	if verb == 'e' && p.pos < len(p.value) && p.value[p.pos] == ' ' {
+		p.pos++
+	}
+	digits := p.pos
+	if verb == 's' && p.pos < len(p.value) && p.value[p.pos] == '-' {
+		p.pos++
+	}
+	for p.pos < len(p.value) && p.pos-digits < strftimeWidths[verb] && isDigit(p.value[p.pos]) {
+		p.pos++
+	}
+	n, err := strconv.Atoi(p.value[digits:p.pos])
+	if err != nil {
+		p.pos = start
+		return 0, p.errorf("expected %%%c", verb)
+	}
+	return n, nil
+}
+
+// name parses one of the names, case-insensitively, preferring the longest match, and returns its index
+func (p *strptimeParser) name(verb byte, names ...string) (int, error) {
+	best, length := -1, 0
+	for k, name := range names {
+		if len(name) > length && len(p.value)-p.pos >= len(name) && strings.EqualFold(p.value[p.pos:p.pos+len(name)], name) {
+			best, length = k, len(name)
+		}
+	}
+	if best < 0 {
+		return 0, p.errorf("expected %%%c", verb)
+	}
+	p.pos += length
+	return best, nil
+}
+
+// offset parses a numeric zone offset: Z, ±hh, ±hhmm or ±hh:mm
+func (p *strptimeParser) offset() (int, error) {
+	s := p.value[p.pos:]
+	if strings.HasPrefix(s, "Z") {
+		p.pos++
+		return 0, nil
+	}
+	if len(s) < 3 || (s[0] != '+' && s[0] != '-') || !isDigit(s[1]) || !isDigit(s[2]) {
+		return 0, p.errorf("expected %%z")
+	}
+	hh, _ := strconv.Atoi(s[1:3])
+	mm, n := 0, 3
+	rest, colon := s[3:], 0
+	if strings.HasPrefix(rest, ":") {
+		rest, colon = rest[1:], 1
+	}
+	if len(rest) >= 2 && isDigit(rest[0]) && isDigit(rest[1]) {
+		mm, _ = strconv.Atoi(rest[:2])
+		n += colon + 2
+	}
+	if hh > 23 || mm > 59 {
+		return 0, p.errorf("invalid offset %q", s[:n])
+	}
+	p.pos += n
+	offset := hh*3600 + mm*60
+	if s[0] == '-' {
+		offset = -offset
+	}
+	return offset, nil
+}
+
+var (
+	shortDays   = []string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"}
+	longDays    = []string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"}
+	shortMonths = []string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"}
+	longMonths  = []string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"}
+)
+
+func (f strftimeFormat) parse(value string, loc *time.Location) (time.Time, error) {
+	p := &strptimeParser{value: value, fields: make(map[byte]int)}
+	for _, item := range f {
+		var n int
+		var err error
+		switch item.verb {
+		case 0:
+			if !strings.HasPrefix(value[p.pos:], item.literal) {
+				return time.Time{}, p.errorf("expected %q", item.literal)
+			}
+			p.pos += len(item.literal)
+			continue
+		case 'a', 'A':
+			n, err = p.name(item.verb, append(append([]string(nil), longDays...), shortDays...)...)
+			n %= 7
+		case 'b', 'h', 'B':
+			n, err = p.name(item.verb, append(append([]string(nil), longMonths...), shortMonths...)...)
+			n = n%12 + 1
+		case 'p':
+			n, err = p.name(item.verb, "AM", "PM")
+		case 'Z':
+			// an abbreviation is made of letters, e.g. EST, or is a signed number, e.g. -03 for America/Sao_Paulo
+			start, isZoneChar := p.pos, isLetter
+			if p.pos < len(value) && (value[p.pos] == '+' || value[p.pos] == '-') {
+				p.pos++
+				isZoneChar = isDigit
+			}
+			name := p.pos
+			for p.pos < len(value) && isZoneChar(value[p.pos]) {
+				p.pos++
+			}
+			if p.pos == name {
+				p.pos = start
+				return time.Time{}, p.errorf("expected %%Z")
+			}
+			p.zone = value[start:p.pos]
+			continue
+		case 'z':
+			n, err = p.offset()
+		default:
+			n, err = p.number(item.verb)
+		}
+		if err != nil {
+			return time.Time{}, err
+		}
+		p.fields[item.verb] = n
+	}
+	if p.pos < len(value) {
+		return time.Time{}, p.errorf("unexpected %q", value[p.pos:])
+	}
+	p.pos = 0 // the remaining errors are about the whole value
+	return p.resolve(loc)
+}
+
+func isLetter(c byte) bool {
+	return c >= 'A' && c <= 'Z' || c >= 'a' && c <= 'z'
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// resolve builds the time from the parsed fields, see Strptime
+func (p *strptimeParser) resolve(loc *time.Location) (time.Time, error) {
+	fields := p.fields
+	has := func(verb byte) bool { _, ok := fields[verb]; return ok }
+	invalid := func(format string, args ...interface{}) (time.Time, error) {
+		return time.Time{}, fmt.Errorf(format, args...)
+	}
+	if has('s') {
+		return time.Unix(int64(fields['s']), 0).In(loc), nil
+	}
+
+	year := fields['Y']
+	if !has('Y') && (has('y') || has('C')) {
+		century := fields['C']
+		if !has('C') {
+			century = 19
+			if fields['y'] < 69 {
+				century = 20
+			}
+		}
+		year = century*100 + fields['y']
+	}
+	weekday, hasWeekday := -1, false // 0 is Sunday
+	for _, verb := range []byte{'a', 'A', 'w'} {
+		if has(verb) {
+			weekday, hasWeekday = fields[verb], true
+		}
+	}
+	if has('u') {
+		weekday, hasWeekday = fields['u']%7, true
+	}
+	if weekday > 6 {
+		return invalid("invalid weekday %d", weekday)
+	}
+
+	var date time.Time
+	switch {
+	case has('V'):
+		isoYear, week := year, fields['V']
+		if has('G') {
+			isoYear = fields['G']
+		}
+		if !hasWeekday {
+			weekday = int(time.Monday)
+		}
+		jan4 := time.Date(isoYear, time.January, 4, 0, 0, 0, 0, time.UTC)
+		monday := jan4.AddDate(0, 0, -((int(jan4.Weekday()) + 6) % 7))
+		date = monday.AddDate(0, 0, 7*(week-1)+(weekday+6)%7)
+		if y, w := date.ISOWeek(); y != isoYear || w != week {
+			return invalid("invalid ISO week %04d-W%02d", isoYear, week)
+		}
+	case has('G'):
+		return invalid("%%G requires %%V")
+	case has('j'):
+		yday := fields['j']
+		date = time.Date(year, time.January, yday, 0, 0, 0, 0, time.UTC)
+		if yday < 1 || date.Year() != year {
+			return invalid("invalid day of the year %d", yday)
+		}
+	default:
+		month, day, hasMonth := 1, 1, false
+		for _, verb := range []byte{'m', 'b', 'h', 'B'} {
+			if has(verb) {
+				month, hasMonth = fields[verb], true
+			}
+		}
+		if has('q') {
+			quarter := fields['q']
+			if quarter < 1 || quarter > 4 {
+				return invalid("invalid quarter %d", quarter)
+			}
+			if !hasMonth {
+				month = 3*quarter - 2
+			} else if (month-1)/3+1 != quarter {
+				return invalid("month %d is not in quarter %d", month, quarter)
+			}
+		}
+		for _, verb := range []byte{'d', 'e'} {
+			if has(verb) {
+				day = fields[verb]
+			}
+		}
+		if month < 1 || month > 12 {
+			return invalid("invalid month %d", month)
+		}
+		if day < 1 || day > daysIn(year, time.Month(month)) {
+			return invalid("invalid day %d of %04d-%02d", day, year, month)
+		}
+		date = time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+		if hasWeekday && int(date.Weekday()) != weekday {
+			return invalid("%s is a %v, not a %v", date.Format("2006-01-02"), date.Weekday(), time.Weekday(weekday))
+		}
+	}
+
+	hour := fields['H']
+	if has('I') {
+		if fields['I'] < 1 || fields['I'] > 12 {
+			return invalid("invalid hour %d", fields['I'])
+		}
+		hour = fields['I'] % 12
+		if fields['p'] == 1 {
+			hour += 12
+		}
+	}
+	if hour > 23 || fields['M'] > 59 || fields['S'] > 59 {
+		return invalid("invalid time %02d:%02d:%02d", hour, fields['M'], fields['S'])
+	}
+	dt := DateTime{Date: DateOf(date), Hour: hour, Minute: fields['M'], Second: fields['S']}
+
+	switch {
+	case has('z'):
+		zone := time.UTC
+		if fields['z'] != 0 || p.zone != "" {
+			zone = time.FixedZone(p.zone, fields['z'])
+		}
+		t := time.Date(dt.Year, dt.Month, dt.Day, dt.Hour, dt.Minute, dt.Second, 0, zone)
+		if zt, err := ResolveOffset(t, loc); err == nil {
+			return zt, nil
+		}
+		return t, nil
+	case p.zone == "UTC" || p.zone == "GMT":
+		return time.Date(dt.Year, dt.Month, dt.Day, dt.Hour, dt.Minute, dt.Second, 0, time.UTC).In(loc), nil
+	case p.zone != "":
+		for _, policy := range []Disambiguation{DisambiguateEarlier, DisambiguateLater} {
+			t, _ := dt.In(loc, policy)
+			if name, _ := t.Zone(); name == p.zone && DateTimeOf(t) == dt {
+				return t, nil
+			}
+		}
+		return invalid("zone %s is not used by %v at %v", p.zone, loc, dt)
+	}
+	return time.Date(dt.Year, dt.Month, dt.Day, dt.Hour, dt.Minute, dt.Second, 0, loc), nil
+}
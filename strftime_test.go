@@ -0,0 +1,162 @@
+package timewalker
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStrftime(t *testing.T) {
+	loc, _ := time.LoadLocation("America/Montreal")
+	tm := parseTime("2024-03-07T17:04:05Z").In(loc)
+	var testData = []struct {
+		format string // strftime format
+		exp    string // expected result
+	}{
+		{"%Y-%m-%d %H:%M:%S", "2024-03-07 12:04:05"},
+		{"%F %T %Z %z", "2024-03-07 12:04:05 EST -0500"},
+		{"%G-W%V-%u %j", "2024-W10-4 067"},
+		{"%Y-Q%q", "2024-Q1"},
+		{"%a %A %b %h %B", "Thu Thursday Mar Mar March"},
+		{"%I:%M %p, %e %D %R", "12:04 PM,  7 03/07/24 12:04"},
+		{"%C %y %w %s", "20 24 4 1709831045"},
+		{"100%%%n%t", "100%\n\t"},
+	}
+	for _, tt := range testData {
+		actual, err := Strftime(tm, tt.format)
+		if err != nil || actual != tt.exp {
+			t.Errorf("Strftime(%v, %q): \nexp: %q, \nact: %q, %v", tm, tt.format, tt.exp, actual, err)
+		}
+	}
+
+	// ISO 8601 week-based years differ from calendar years around January 1st
+	for _, tt := range []struct{ inp, exp string }{
+		{"2024-12-30T00:00:00Z", "2025-W01-1"},
+		{"2021-01-03T00:00:00Z", "2020-W53-7"},
+	} {
+		if actual, _ := Strftime(parseTime(tt.inp), "%G-W%V-%u"); actual != tt.exp {
+			t.Errorf("Strftime(%v, %%G-W%%V-%%u): exp: %v act: %v", tt.inp, tt.exp, actual)
+		}
+	}
+
+	if _, err := Strftime(tm, "%Y-%Q"); err == nil || err.Error() != `invalid strftime format "%Y-%Q": unsupported conversion %Q at position 3` {
+		t.Errorf("Strftime: unexpected error %v", err)
+	}
+}
+
+func TestStrptime(t *testing.T) {
+	loc, _ := time.LoadLocation("America/Montreal")
+	var testData = []struct {
+		format string         // strftime format
+		value  string         // parsed value
+		loc    *time.Location // Location
+		exp    string         // expected time, in RFC 3339 format
+	}{
+		{"%Y-%m-%d", "2024-03-07", time.UTC, "2024-03-07T00:00:00Z"},
+		{"%Y%m%d", "20240307", loc, "2024-03-07T00:00:00-05:00"},
+		{"%Y-%m-%d %H:%M:%S", "2024-3-7 9:4:5", nil, "2024-03-07T09:04:05Z"},
+		{"%G-W%V", "2024-W10", time.UTC, "2024-03-04T00:00:00Z"},
+		{"%G-W%V-%u", "2020-W53-7", time.UTC, "2021-01-03T00:00:00Z"},
+		{"%Y-%j", "2024-067", time.UTC, "2024-03-07T00:00:00Z"},
+		{"%Y-%j", "2024-366", time.UTC, "2024-12-31T00:00:00Z"},
+		{"%Y-Q%q", "2024-Q3", time.UTC, "2024-07-01T00:00:00Z"},
+		{"%Y-Q%q-%m-%d", "2024-Q3-09-30", time.UTC, "2024-09-30T00:00:00Z"},
+		{"%a, %d %b %Y %T %z", "Thu, 07 Mar 2024 12:04:05 -0500", loc, "2024-03-07T12:04:05-05:00"},
+		{"%d %B %Y %H:%M %z", "07 march 2024 12:04 +05:30", loc, "2024-03-07T12:04:00+05:30"},
+		{"%F %T%z", "2024-03-07 17:04:05Z", loc, "2024-03-07T17:04:05Z"},
+		{"%F %T %Z", "2008-11-02 01:30:00 EDT", loc, "2008-11-02T01:30:00-04:00"},
+		{"%F %T %Z", "2008-11-02 01:30:00 EST", loc, "2008-11-02T01:30:00-05:00"},
+		{"%F %T %Z", "2008-11-02 06:30:00 UTC", loc, "2008-11-02T01:30:00-05:00"},
+		{"%m/%d/%y %I:%M %p", "03/07/24 12:04 am", time.UTC, "2024-03-07T00:04:00Z"},
+		{"%m/%d/%y %I:%M %p", "03/07/69 12:04 PM", time.UTC, "1969-03-07T12:04:00Z"},
+		{"%s", "1709831045", loc, "2024-03-07T12:04:05-05:00"},
+		{"%B %Y", "March 2024", time.UTC, "2024-03-01T00:00:00Z"},
+		{"%H:%M", "12:30", time.UTC, "0000-01-01T12:30:00Z"},
+	}
+	for _, tt := range testData {
+		actual, err := Strptime(tt.format, tt.value, tt.loc)
+		if err != nil || actual.Format(time.RFC3339) != tt.exp {
+			t.Errorf("Strptime(%q, %q): \nexp: %v, \nact: %v, %v", tt.format, tt.value, tt.exp, actual, err)
+		}
+		if tt.loc != nil && tt.loc != time.UTC && !strings.Contains(tt.format, "%z") && actual.Location() != tt.loc {
+			t.Errorf("Strptime(%q, %q): exp Location %v act: %v", tt.format, tt.value, tt.loc, actual.Location())
+		}
+	}
+}
+
+func TestStrptimeNumericZone(t *testing.T) {
+	// zones without an abbreviation, e.g. America/Sao_Paulo since 2017, are named by their offset, which %Z parses back
+	loc, _ := time.LoadLocation("America/Sao_Paulo")
+	for _, tm := range []time.Time{
+		time.Date(2024, time.March, 7, 12, 4, 5, 0, loc),
+		time.Date(2018, time.December, 7, 12, 4, 5, 0, loc), // daylight savings time, -02
+	} {
+		s, _ := Strftime(tm, "%F %T %Z")
+		actual, err := Strptime("%F %T %Z", s, loc)
+		if err != nil || !actual.Equal(tm) || actual.Location() != loc {
+			t.Errorf("Strptime(%q): exp: %v, act: %v, %v", s, tm, actual, err)
+		}
+	}
+}
+
+func TestStrptimeErrors(t *testing.T) {
+	loc, _ := time.LoadLocation("America/Montreal")
+	var testData = []struct {
+		format string // strftime format
+		value  string // parsed value
+		exp    string // expected error, after the common prefix
+	}{
+		{"%Y-%m-%d", "2024-03", `expected "-" at position 7`},
+		{"%Y-%m-%d", "2024-03-07x", `unexpected "x" at position 10`},
+		{"%Y-%m-%d", "2024-xx-07", `expected %m at position 5`},
+		{"%Y-%m-%d", "2024-13-07", `invalid month 13`},
+		{"%Y-%m-%d", "2023-02-29", `invalid day 29 of 2023-02`},
+		{"%a %F", "Fri 2024-03-07", `2024-03-07 is a Thursday, not a Friday`},
+		{"%b %Y", "Mars 2024", `expected " " at position 3`},
+		{"%B %Y", "Mxy 2024", `expected %B at position 0`},
+		{"%G-W%V", "2023-W53", `invalid ISO week 2023-W53`},
+		{"%G", "2023", `%G requires %V`},
+		{"%Y-%j", "2023-366", `invalid day of the year 366`},
+		{"%Y-Q%q", "2023-Q5", `invalid quarter 5`},
+		{"%Y-Q%q-%m", "2023-Q2-07", `month 7 is not in quarter 2`},
+		{"%I %p", "13 PM", `invalid hour 13`},
+		{"%H:%M", "24:00", `invalid time 24:00:00`},
+		{"%F %z", "2024-03-07 0500", `expected %z at position 11`},
+		{"%F %T %Z", "2024-03-07 12:00:00 PST", `zone PST is not used by America/Montreal at 2024-03-07T12:00:00`},
+		{"%F %T %Z", "2024-07-07 12:00:00 EST", `zone EST is not used by America/Montreal at 2024-07-07T12:00:00`},
+		{"%F %T %Z", "2024-07-07 12:00:00 -03", `zone -03 is not used by America/Montreal at 2024-07-07T12:00:00`},
+		{"%F %T %Z", "2024-07-07 12:00:00 -", `expected %Z at position 20`},
+		{"%F %T %Z", "2024-07-07 12:00:00 60", `expected %Z at position 20`},
+		{"%T", "12:00:60", `invalid time 12:00:60`},
+	}
+	for _, tt := range testData {
+		_, err := Strptime(tt.format, tt.value, loc)
+		exp := fmt.Sprintf("invalid time %q for strftime format %q: %s", tt.value, tt.format, tt.exp)
+		if err == nil || err.Error() != exp {
+			t.Errorf("Strptime(%q, %q): \nexp: %v, \nact: %v", tt.format, tt.value, exp, err)
+		}
+	}
+	if _, err := Strptime("%Y-%", "2024-", nil); err == nil || err.Error() != `invalid strftime format "%Y-%": trailing % at position 3` {
+		t.Errorf("Strptime: unexpected error %v", err)
+	}
+}
+
+func ExampleStrftime() {
+	t := time.Date(2024, time.March, 7, 12, 4, 5, 0, time.UTC)
+	s, _ := Strftime(t, "%A %d %B %Y, week %G-W%V, day %j")
+	fmt.Println(s)
+	// Output:
+	// Thursday 07 March 2024, week 2024-W10, day 067
+}
+
+func ExampleStrptime() {
+	loc, _ := time.LoadLocation("America/Montreal")
+	for _, s := range []string{"2008-11-02 01:30 EDT", "2008-11-02 01:30 EST"} {
+		t, _ := Strptime("%Y-%m-%d %H:%M %Z", s, loc)
+		fmt.Println(t.UTC())
+	}
+	// Output:
+	// 2008-11-02 05:30:00 +0000 UTC
+	// 2008-11-02 06:30:00 +0000 UTC
+}
@@ -0,0 +1,182 @@
+package timewalker
+
+import (
+	"fmt"
+	"time"
+)
+
+// Tick is a position on a time axis
+type Tick struct {
+	Time  time.Time
+	Label string
+	// Major ticks are the first of a larger period, e.g. the first hour tick of a day; their Label names that period
+	Major bool
+}
+
+// tickStep is a candidate step between Ticks
+type tickStep struct {
+	period  Period
+	nominal time.Duration // approximate length, to compare with the visible interval
+	unit    Duration      // calendar unit of the steps of a Day or more; for smaller steps, the wall clock is used
+	count   int           // number of units in a step
+	parent  func(t time.Time) time.Time
+	minor   func(t time.Time) string
+	major   func(t time.Time) string
+}
+
+const (
+	nominalDay  = 24 * time.Hour
+	nominalYear = 36524 * nominalDay / 100
+)
+
+func layoutLabel(layout string) func(t time.Time) string {
+	return func(t time.Time) string { return t.Format(layout) }
+}
+
+// floorClock returns the start of the wall clock minute or hour of t, in t's Location
+func floorClock(unit time.Duration) func(t time.Time) time.Time {
+	return func(t time.Time) time.Time {
+		year, month, day := t.Date()
+		hour, min, _ := t.Clock()
+		if unit == time.Hour {
+			min = 0
+		}
+		return time.Date(year, month, day, hour, min, 0, 0, t.Location())
+	}
+}
+
+// floorYears returns the start of the n-year period of t, e.g. the decade for 10
+func floorYears(n int) func(t time.Time) time.Time {
+	return func(t time.Time) time.Time {
+		return time.Date(t.Year()-mod(t.Year(), n), time.January, 1, 0, 0, 0, 0, t.Location())
+	}
+}
+
+func mod(a, n int) int {
+	return ((a % n) + n) % n
+}
+
+func quarterLabel(t time.Time) string {
+	return fmt.Sprintf("Q%d", (int(t.Month())-1)/3+1)
+}
+
+// tickSteps are the candidate steps, from the smallest
+var tickSteps = func() []tickStep {
+	var steps []tickStep
+	for _, s := range []int{1, 5, 15, 30} {
+		steps = append(steps, tickStep{period: Period{Seconds: s}, nominal: time.Duration(s) * time.Second,
+			parent: floorClock(time.Minute), minor: layoutLabel("15:04:05"), major: layoutLabel("15:04")})
+	}
+	for _, m := range []int{1, 5, 15, 30} {
+		steps = append(steps, tickStep{period: Period{Minutes: m}, nominal: time.Duration(m) * time.Minute,
+			parent: floorClock(time.Hour), minor: layoutLabel("15:04"), major: layoutLabel("15:04")})
+	}
+	for _, h := range []int{1, 3, 6, 12} {
+		steps = append(steps, tickStep{period: Period{Hours: h}, nominal: time.Duration(h) * time.Hour,
+			parent: Day.Floor, minor: layoutLabel("15:04"), major: layoutLabel("Jan 2")})
+	}
+	steps = append(steps,
+		tickStep{period: Day.Period(), nominal: nominalDay, unit: Day, count: 1,
+			parent: Month.Floor, minor: layoutLabel("Jan 2"), major: layoutLabel("January")},
+		tickStep{period: Week.Period(), nominal: 7 * nominalDay, unit: Week, count: 1,
+			parent: Month.Floor, minor: layoutLabel("Jan 2"), major: layoutLabel("January")},
+		tickStep{period: Month.Period(), nominal: nominalYear / 12, unit: Month, count: 1,
+			parent: Year.Floor, minor: layoutLabel("Jan"), major: layoutLabel("2006")},
+		tickStep{period: Quarter.Period(), nominal: nominalYear / 4, unit: Quarter, count: 1,
+			parent: Year.Floor, minor: quarterLabel, major: layoutLabel("2006")},
+	)
+	for _, y := range []int{1, 2, 5, 10, 20, 50, 100} {
+		parent := floorYears(10)
+		if y >= 10 {
+			parent = floorYears(100)
+		}
+		steps = append(steps, tickStep{period: Period{Years: y}, nominal: time.Duration(y) * nominalYear, unit: Year, count: y,
+			parent: parent, minor: layoutLabel("2006"), major: layoutLabel("2006")})
+	}
+	return steps
+}()
+
+// Ticks returns at most n Ticks for a time axis showing the receiver's interval, Start and End included, in the viewer's Location.
+// The step is the smallest of 1, 5, 15 and 30 seconds or minutes, 1, 3, 6 and 12 hours, a Day, a Week, a Month, a Quarter,
+// and 1, 2, 5, 10, 20, 50 and 100 Years, giving from 1 to n Ticks, and is also returned; when none does, e.g. for n=1 on a day, it is an error.
+// Ticks are aligned on calendar boundaries in loc: steps of a Day or more start at Duration.Ceil of Start,
+// e.g. Mondays for Weeks, and years which are multiples of the step; smaller steps are aligned on the wall clock, e.g. 00:00, 06:00, 12:00,
+// and are resolved with DisambiguateShiftForward when they fall in a daylight savings gap.
+func Ticks(i Interval, n int, loc *time.Location) ([]Tick, Period, error) {
+	if i.Bounds&(StartUnbounded|EndUnbounded) != 0 {
+		return nil, Period{}, fmt.Errorf("cannot place Ticks on an unbounded Interval: %v", i)
+	}
+	if n < 1 {
+		return nil, Period{}, fmt.Errorf("invalid number of Ticks: %d", n)
+	}
+	if !i.End.After(i.Start) {
+		return nil, Period{}, fmt.Errorf("cannot place Ticks on an empty Interval: %v", i)
+	}
+	start, end := i.Start.In(loc), i.End.In(loc)
+	// in seconds, as a time.Duration saturates after about 292 years
+	span := float64(end.Unix()-start.Unix()) + float64(end.Nanosecond()-start.Nanosecond())/1e9
+	var s tickStep
+	var times []time.Time
+	for _, candidate := range tickSteps {
+		// steps are irregular, by less than half, so skip the candidates which certainly give too many Ticks before counting them
+		if span/candidate.nominal.Seconds() > float64(2*n+2) {
+			continue
+		}
+		candidateTimes, err := candidate.times(start, end, loc)
+		if err != nil {
+			return nil, Period{}, err
+		}
+		if len(candidateTimes) >= 1 && len(candidateTimes) <= n {
+			s, times = candidate, candidateTimes
+			break
+		}
+	}
+	if times == nil {
+		return nil, Period{}, fmt.Errorf("no step gives from 1 to %d Ticks on %v", n, i)
+	}
+
+	ticks := make([]Tick, len(times))
+	for k, t := range times {
+		// a Tick is major when its parent period starts after the previous step
+		prev := t.Add(-s.nominal)
+		if s.count > 0 {
+			prev, _ = s.unit.AddN(t, -s.count, OverflowNormalize)
+		}
+		ticks[k] = Tick{Time: t, Label: s.minor(t)}
+		if s.parent(t).After(prev) {
+			ticks[k].Major = true
+			ticks[k].Label = s.major(t)
+		}
+	}
+	return ticks, s.period, nil
+}
+
+// times returns the times of the Ticks of the step, from start to end, both included
+func (s tickStep) times(start, end time.Time, loc *time.Location) ([]time.Time, error) {
+	var times []time.Time
+	if s.count == 0 {
+		// on the wall clock, from the start of the parent period, which the step divides
+		from := DateTimeOf(s.parent(start))
+		to := DateTimeOf(end).Add(Period{Nanoseconds: 1})
+		ch, err := WalkDateTimes(from, to, s.period, loc, DisambiguateShiftForward)
+		if err != nil {
+			return nil, err
+		}
+		for t := range ch {
+			if !t.Before(start) && !t.After(end) {
+				times = append(times, t)
+			}
+		}
+		return times, nil
+	}
+	ch, err := Walk(s.unit.Ceil(start), s.unit.AddTo(end), s.unit)
+	if err != nil {
+		return nil, err
+	}
+	for t := range ch {
+		if !t.After(end) && mod(t.Year(), s.count) == 0 {
+			times = append(times, t)
+		}
+	}
+	return times, nil
+}
@@ -0,0 +1,130 @@
+package timewalker
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+// formatTicks summarizes Ticks, e.g. 00:00* 06:00 for a major and a minor Tick
+func formatTicks(ticks []Tick) string {
+	var s []string
+	for _, tick := range ticks {
+		label := tick.Label
+		if tick.Major {
+			label += "*"
+		}
+		s = append(s, label)
+	}
+	return strings.Join(s, " ")
+}
+
+func TestTicks(t *testing.T) {
+	loc, _ := time.LoadLocation("America/Montreal")
+	var testData = []struct {
+		i    Interval       // visible interval
+		n    int            // target number of Ticks
+		loc  *time.Location // viewer's Location
+		step string         // expected step
+		exp  string         // expected Ticks
+	}{
+		{
+			parseIntvl("2024-03-07T12:00:10Z", "2024-03-07T12:02:00Z"), 10, time.UTC,
+			"PT15S", "12:00:15 12:00:30 12:00:45 12:01* 12:01:15 12:01:30 12:01:45 12:02*",
+		}, {
+			parseIntvl("2024-03-07T12:07:00Z", "2024-03-07T13:10:00Z"), 5, time.UTC,
+			"PT15M", "12:15 12:30 12:45 13:00*",
+		}, {
+			parseIntvl("2024-03-07T05:00:00Z", "2024-03-08T05:00:00Z"), 9, loc,
+			"PT3H", "Mar 7* 03:00 06:00 09:00 12:00 15:00 18:00 21:00 Mar 8*",
+		}, { // 9 Ticks of 3 hours would be too many
+			parseIntvl("2024-03-07T05:00:00Z", "2024-03-08T05:00:00Z"), 8, loc,
+			"PT6H", "Mar 7* 06:00 12:00 18:00 Mar 8*",
+		}, { // the 02:00 Tick does not exist, and is shifted to 03:00
+			parseIntvl("2024-03-10T05:00:00Z", "2024-03-10T09:00:00Z"), 5, loc,
+			"PT1H", "Mar 10* 01:00 03:00 04:00 05:00",
+		}, {
+			parseIntvl("2024-02-20T00:00:00Z", "2024-03-25T00:00:00Z"), 6, loc,
+			"P1W", "Feb 26 March* Mar 11 Mar 18",
+		}, {
+			parseIntvl("2024-02-27T00:00:00Z", "2024-03-03T00:00:00Z"), 7, time.UTC,
+			"P1D", "Feb 27 Feb 28 Feb 29 March* Mar 2 Mar 3",
+		}, {
+			parseIntvl("2023-10-15T00:00:00Z", "2024-04-01T00:00:00Z"), 6, time.UTC,
+			"P1M", "Nov Dec 2024* Feb Mar Apr",
+		}, {
+			parseIntvl("2023-01-01T00:00:00Z", "2025-01-01T00:00:00Z"), 10, time.UTC,
+			"P3M", "2023* Q2 Q3 Q4 2024* Q2 Q3 Q4 2025*",
+		}, {
+			parseIntvl("1983-06-01T00:00:00Z", "2031-01-01T00:00:00Z"), 6, time.UTC,
+			"P10Y", "1990 2000* 2010 2020 2030",
+		}, {
+			parseIntvl("1000-01-01T00:00:00Z", "3000-01-01T00:00:00Z"), 21, time.UTC,
+			"P100Y", "1000* 1100* 1200* 1300* 1400* 1500* 1600* 1700* 1800* 1900* 2000* 2100* 2200* 2300* 2400* 2500* 2600* 2700* 2800* 2900* 3000*",
+		},
+	}
+	for _, tt := range testData {
+		ticks, step, err := Ticks(tt.i, tt.n, tt.loc)
+		if err != nil || step.String() != tt.step || formatTicks(ticks) != tt.exp {
+			t.Errorf("Ticks(%v, %d): \nexp: %v %v, \nact: %v %v, %v", tt.i, tt.n, tt.step, tt.exp, step, formatTicks(ticks), err)
+		}
+		for _, tick := range ticks {
+			if tick.Time.Location() != tt.loc || tick.Time.Before(tt.i.Start) || tick.Time.After(tt.i.End) {
+				t.Errorf("Ticks(%v, %d): unexpected Tick at %v", tt.i, tt.n, tick.Time)
+			}
+		}
+	}
+}
+
+func TestTicksAtMostN(t *testing.T) {
+	loc, _ := time.LoadLocation("America/Montreal")
+	start := parseTime("2024-03-07T05:00:00Z")
+	for _, span := range []time.Duration{time.Minute, time.Hour, 24 * time.Hour, 30 * 24 * time.Hour, 400 * 24 * time.Hour, 40000 * 24 * time.Hour} {
+		for n := 1; n <= 12; n++ {
+			i := Interval{Start: start, End: start.Add(span)}
+			ticks, _, err := Ticks(i, n, loc)
+			if err == nil && (len(ticks) < 1 || len(ticks) > n) {
+				t.Errorf("Ticks(%v, %d): %d Ticks", i, n, len(ticks))
+			}
+		}
+	}
+}
+
+func TestTicksErrors(t *testing.T) {
+	var testData = []struct {
+		i   Interval // visible interval
+		n   int      // target number of Ticks
+		exp string   // expected error
+	}{
+		{parseIntvl("2024-03-07T00:00:00Z", "2024-03-08T00:00:00Z"), 0, "invalid number of Ticks: 0"},
+		{parseIntvl("2024-03-07T00:00:00Z", "2024-03-08T00:00:00Z"), 1, "no step gives from 1 to 1 Ticks on [2024-03-07T00:00:00Z, 2024-03-08T00:00:00Z)"},
+		{parseIntvl("1000-01-01T00:00:00Z", "3000-01-01T00:00:00Z"), 20, "no step gives from 1 to 20 Ticks on [1000-01-01T00:00:00Z, 3000-01-01T00:00:00Z)"},
+		{parseIntvl("2024-03-08T00:00:00Z", "2024-03-07T00:00:00Z"), 5, "cannot place Ticks on an empty Interval: [2024-03-08T00:00:00Z, 2024-03-07T00:00:00Z)"},
+		{Interval{Start: parseTime("2024-03-07T00:00:00Z"), Bounds: EndUnbounded}, 5, "cannot place Ticks on an unbounded Interval: [2024-03-07T00:00:00Z, +∞)"},
+	}
+	for _, tt := range testData {
+		if _, _, err := Ticks(tt.i, tt.n, time.UTC); err == nil || err.Error() != tt.exp {
+			t.Errorf("Ticks(%v, %d): \nexp: %v, \nact: %v", tt.i, tt.n, tt.exp, err)
+		}
+	}
+}
+
+func ExampleTicks() {
+	loc, _ := time.LoadLocation("Asia/Kolkata")
+	i := Interval{
+		Start: time.Date(2024, time.March, 7, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2024, time.March, 8, 0, 0, 0, 0, time.UTC),
+	}
+	ticks, step, _ := Ticks(i, 5, loc)
+	fmt.Println(step)
+	for _, tick := range ticks {
+		fmt.Printf("%v %-6s major: %v\n", tick.Time.Format(time.RFC3339), tick.Label, tick.Major)
+	}
+	// Output:
+	// PT6H
+	// 2024-03-07T06:00:00+05:30 06:00  major: false
+	// 2024-03-07T12:00:00+05:30 12:00  major: false
+	// 2024-03-07T18:00:00+05:30 18:00  major: false
+	// 2024-03-08T00:00:00+05:30 Mar 8  major: true
+}
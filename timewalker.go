@@ -2,6 +2,7 @@
 package timewalker
 
 import (
+	"context"
 	"fmt"
 	"time"
 )
@@ -14,6 +15,10 @@ const (
 	Day Duration = iota
 	Month
 	Year
+	// Week starts on Monday, as ISO 8601 weeks
+	Week
+	// Quarter starts in January, April, July or October
+	Quarter
 )
 
 // Produces Human readble represations of the Duration enum values
@@ -26,6 +31,10 @@ func (d Duration) String() string {
 		str = "Month"
 	case Year:
 		str = "Year"
+	case Week:
+		str = "Week"
+	case Quarter:
+		str = "Quarter"
 	}
 	return str
 }
@@ -40,6 +49,10 @@ func (d Duration) Floor(t time.Time) time.Time {
 		t = time.Date(year, month, 1, 0, 0, 0, 0, t.Location())
 	case Year:
 		t = time.Date(year, time.January, 1, 0, 0, 0, 0, t.Location())
+	case Week:
+		t = time.Date(year, month, day-(int(t.Weekday())+6)%7, 0, 0, 0, 0, t.Location())
+	case Quarter:
+		t = time.Date(year, month-(month-1)%3, 1, 0, 0, 0, 0, t.Location())
 	}
 	return t
 }
@@ -63,11 +76,18 @@ func (d Duration) AddTo(t time.Time) time.Time {
 		yr, mo, dy = 0, 1, 0
 	case Year:
 		yr, mo, dy = 1, 0, 0
+	case Week:
+		yr, mo, dy = 0, 0, 7
+	case Quarter:
+		yr, mo, dy = 0, 3, 0
 	}
 	return t.AddDate(yr, mo, dy)
 }
 
-// Walk produces times from a (incl) to b (excl)
+// Walk produces times from a (incl) to b (excl).
+// When a and b are in different Locations, each is rounded down (Floor) in its own Location,
+// times are produced in a's Location, and the walk stops at the instant of b's rounded boundary, so the last time may not be on a boundary of b's Location.
+// To walk in a single Location, use Interval.WalkWith.
 func Walk(a, b time.Time, d Duration) (<-chan time.Time, error) {
 	ch := make(chan time.Time)
 	ra := d.Floor(a)
@@ -91,15 +111,26 @@ func Walk(a, b time.Time, d Duration) (<-chan time.Time, error) {
 	return ch, nil
 }
 
-// Interval represents a time interval from [Start,End)
+// Interval represents a time interval from [Start,End), unless its Bounds say otherwise
 type Interval struct {
-	Start time.Time
-	End   time.Time
+	Start  time.Time
+	End    time.Time
+	Bounds Bounds
 }
 
+// String formats the receiver as [Start, End), where an unbounded Start or End is written -∞ or +∞, e.g. (-∞, 2001-01-01T00:00:00Z),
+// and the brackets reflect the Bounds, e.g. [2001-01-01T00:00:00Z, 2001-01-31T00:00:00Z]
 func (i Interval) String() string {
 	layout := time.RFC3339
-	return fmt.Sprintf("[%s, %s)", i.Start.Format(layout), i.End.Format(layout))
+	left, right := i.brackets()
+	start, end := left+i.Start.Format(layout), i.End.Format(layout)+right
+	if i.Bounds&StartUnbounded != 0 {
+		start = "(-∞"
+	}
+	if i.Bounds&EndUnbounded != 0 {
+		end = "+∞)"
+	}
+	return fmt.Sprintf("%s, %s", start, end)
 }
 
 /*
@@ -108,9 +139,22 @@ Round normalizes an Interval's representations
 	-Swap Start,End if appropriate (if End.Before(Start))
 	-Round down (Floor) Start, Round up (Ceil) End, both on Duration boundary
 	-Make sure we have at least one interval.
+Exclusive starts and inclusive ends are first normalized, see HalfOpen.
+Unbounded boundaries are left as they are, so only a bounded Start or End is rounded,
+and only intervals bounded on both sides are swapped, extended or checked for Location.
 */
 func (i Interval) Round(d Duration) (Interval, error) {
 	// BUG(daneroo): Interval Rounding behavior is not well defined yet. This is also an example of a BUG comment showing up in the godocs
+	i = i.HalfOpen(d)
+	if i.Bounds&(StartUnbounded|EndUnbounded) != 0 {
+		if i.Bounds&StartUnbounded == 0 {
+			i.Start = d.Floor(i.Start)
+		}
+		if i.Bounds&EndUnbounded == 0 {
+			i.End = d.Ceil(i.End)
+		}
+		return i, nil
+	}
 	if i.End.Before(i.Start) {
 		i.End, i.Start = i.Start, i.End
 	}
@@ -131,8 +175,22 @@ func (i Interval) Round(d Duration) (Interval, error) {
 	return i, nil
 }
 
-// Walk traverses the receiver's interval in steps of  the given duration
+// Walk traverses the receiver's interval in steps of  the given duration.
+// An interval with an unbounded Start cannot be walked, and one with an unbounded End must be walked with WalkContext,
+// so that the walk can be stopped.
 func (i Interval) Walk(d Duration) (<-chan Interval, error) {
+	if i.Bounds&EndUnbounded != 0 {
+		return nil, errWalkForever(i, "WalkContext")
+	}
+	return i.WalkContext(context.Background(), d)
+}
+
+// WalkContext is like Walk, but the walk stops, and the channel is closed, when ctx is done.
+// An interval with an unbounded End is walked lazily, forever, until ctx is done, so the consumer decides when to stop.
+func (i Interval) WalkContext(ctx context.Context, d Duration) (<-chan Interval, error) {
+	if i.Bounds&StartUnbounded != 0 {
+		return nil, fmt.Errorf("cannot walk an Interval with an unbounded Start: %v", i)
+	}
 	// Round interval
 	ri, err := i.Round(d)
 	// TODO(daneroo) What is the idomatic way of returning the channel on error condition
@@ -143,13 +201,35 @@ func (i Interval) Walk(d Duration) (<-chan Interval, error) {
 	ch := make(chan Interval)
 
 	go func() {
+		defer close(ch)
 		start := ri.Start
-		for start.Before(ri.End) {
+		for ri.endsAfter(start) {
 			end := d.AddTo(start)
-			ch <- Interval{Start: start, End: end}
+			if !sendInterval(ctx, ch, Interval{Start: start, End: end}) {
+				return
+			}
 			start = end
 		}
-		close(ch)
 	}()
 	return ch, nil
 }
+
+// sendInterval sends i on ch, unless ctx is done first, and reports whether it was sent
+func sendInterval(ctx context.Context, ch chan<- Interval, i Interval) bool {
+	select {
+	case ch <- i:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// errWalkForever is the error of a walker without a Context, for an interval which would be walked forever
+func errWalkForever(i Interval, walker string) error {
+	return fmt.Errorf("cannot walk an Interval with an unbounded End without a Context, see %s: %v", walker, i)
+}
+
+// endsAfter reports whether the receiver's End is after t, which is always the case when End is unbounded
+func (i Interval) endsAfter(t time.Time) bool {
+	return i.Bounds&EndUnbounded != 0 || t.Before(i.End)
+}
@@ -1,6 +1,7 @@
 package timewalker
 
 import (
+	"context"
 	"fmt"
 	"testing"
 	"time"
@@ -59,19 +60,24 @@ func Example_parseTimeInLocation() {
 	loc, _ := time.LoadLocation("America/Montreal")
 	fmt.Println(parseTime("2001-02-03T12:45:56Z").In(loc))
 
-	// Parse EST time directly
-	// TODO(daneroo) this seems to depend on Local timezone being set to America/Montreal or equiv...
-	// So we added TZ=America/Montreal in CI
-	fmt.Println(parseTime("2001-02-03T07:45:56-05:00"))
+	// Parse EST time directly into the Location, whatever the Local timezone is
+	t, _ := ParseRFC3339In("2001-02-03T07:45:56-05:00", loc)
+	fmt.Println(t)
 
 	// Parse EDT time directly
-	fmt.Println(parseTime("2001-07-03T07:45:56-04:00"))
+	t, _ = ParseRFC3339In("2001-07-03T07:45:56-04:00", loc)
+	fmt.Println(t)
+
+	// The offset must match the Location at that instant
+	_, err := ParseRFC3339In("2001-07-03T07:45:56-05:00", loc)
+	fmt.Println(err)
 
 	// Output:
 	// 2001-02-03 12:45:56 +0000 UTC
 	// 2001-02-03 07:45:56 -0500 EST
 	// 2001-02-03 07:45:56 -0500 EST
 	// 2001-07-03 07:45:56 -0400 EDT
+	// offset of 2001-07-03T07:45:56-05:00 does not match America/Montreal: 2001-07-03T08:45:56-04:00
 }
 
 var durationTests = []struct {
@@ -81,6 +87,9 @@ var durationTests = []struct {
 	{Day, "Day"},
 	{Month, "Month"},
 	{Year, "Year"},
+	{Week, "Week"},
+	{Quarter, "Quarter"},
+	{Duration(42), "Invalid"},
 }
 
 func TestDuration(t *testing.T) {
@@ -124,6 +133,18 @@ func TestDurationFloor(t *testing.T) {
 			inp: parseTime("2001-02-03T12:45:56Z"),
 			dur: Year,
 			exp: parseTime("2001-01-01T00:00:00Z"),
+		}, { //Week, Saturday to Monday across months
+			inp: parseTime("2001-02-03T12:45:56Z"),
+			dur: Week,
+			exp: parseTime("2001-01-29T00:00:00Z"),
+		}, { //Week, Sunday
+			inp: parseTime("2001-02-04T12:45:56Z"),
+			dur: Week,
+			exp: parseTime("2001-01-29T00:00:00Z"),
+		}, { //Quarter
+			inp: parseTime("2001-06-30T12:45:56Z"),
+			dur: Quarter,
+			exp: parseTime("2001-04-01T00:00:00Z"),
 		},
 	}
 	for _, tt := range testData {
@@ -156,6 +177,18 @@ func TestDurationCeil(t *testing.T) {
 			inp: parseTime("2001-02-03T12:45:56Z"),
 			dur: Year,
 			exp: parseTime("2002-01-01T00:00:00Z"),
+		}, { // Week, already on Boundary
+			inp: parseTime("2001-01-29T00:00:00Z"),
+			dur: Week,
+			exp: parseTime("2001-01-29T00:00:00Z"),
+		}, { // Week
+			inp: parseTime("2001-02-03T12:45:56Z"),
+			dur: Week,
+			exp: parseTime("2001-02-05T00:00:00Z"),
+		}, { // Quarter
+			inp: parseTime("2001-11-03T12:45:56Z"),
+			dur: Quarter,
+			exp: parseTime("2002-01-01T00:00:00Z"),
 		},
 	}
 	for _, tt := range testData {
@@ -225,6 +258,14 @@ func TestDurationAdding(t *testing.T) {
 			inp: parseTime("2001-02-03T12:45:56Z"),
 			dur: Year,
 			exp: parseTime("2002-02-03T12:45:56Z"),
+		}, { //Week
+			inp: parseTime("2001-02-03T12:45:56Z"),
+			dur: Week,
+			exp: parseTime("2001-02-10T12:45:56Z"),
+		}, { //Quarter
+			inp: parseTime("2001-11-03T12:45:56Z"),
+			dur: Quarter,
+			exp: parseTime("2002-02-03T12:45:56Z"),
 		},
 	}
 	for _, tt := range testData {
@@ -395,3 +436,105 @@ func parseTime(ts string) time.Time {
 func parseIntvl(a, b string) Interval {
 	return Interval{Start: parseTime(a), End: parseTime(b)}
 }
+
+func TestRoundUnbounded(t *testing.T) {
+	var roundTests = []struct {
+		inp Interval // input
+		dur Duration // Rounding duration
+		exp Interval // expected result
+	}{
+		{
+			inp: Interval{Start: parseTime("2000-01-01T12:00:00Z"), Bounds: EndUnbounded},
+			dur: Day,
+			exp: Interval{Start: parseTime("2000-01-01T00:00:00Z"), Bounds: EndUnbounded},
+		}, {
+			inp: Interval{End: parseTime("2000-01-01T12:00:00Z"), Bounds: StartUnbounded},
+			dur: Month,
+			exp: Interval{End: parseTime("2000-02-01T00:00:00Z"), Bounds: StartUnbounded},
+		}, {
+			inp: Interval{Bounds: StartUnbounded | EndUnbounded},
+			dur: Year,
+			exp: Interval{Bounds: StartUnbounded | EndUnbounded},
+		},
+	}
+
+	for _, tt := range roundTests {
+		actual, err := tt.inp.Round(tt.dur)
+		if err != nil || actual != tt.exp {
+			t.Errorf("%v.Round(%s): \nexp: %v, \nact: %v, %v", tt.inp, tt.dur, tt.exp, actual, err)
+		}
+	}
+}
+
+func TestWalkUnbounded(t *testing.T) {
+	if _, err := (Interval{End: parseTime("2000-01-01T00:00:00Z"), Bounds: StartUnbounded}).Walk(Day); err == nil {
+		t.Error("Expected error walking an unbounded Start")
+	}
+
+	unbounded := Interval{Start: parseTime("2000-01-01T12:00:00Z"), Bounds: EndUnbounded}
+	if _, err := unbounded.Walk(Year); err == nil {
+		t.Error("Expected error walking an unbounded End without a Context")
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	ch, err := unbounded.WalkContext(ctx, Year)
+	if err != nil {
+		t.Fatalf("Unexpected error walking an unbounded End: %v", err)
+	}
+	// lazily infinite: take the first few
+	exp := []string{"2000-01-01T00:00:00Z", "2001-01-01T00:00:00Z", "2002-01-01T00:00:00Z"}
+	for _, e := range exp {
+		i := <-ch
+		if i.Start != parseTime(e) || i.Bounds != 0 {
+			t.Errorf("Walking an unbounded End: exp: %s act: %v", e, i)
+		}
+	}
+	// cancelling stops the walk, and closes the channel
+	cancel()
+	for range ch {
+	}
+}
+
+func TestWalkContextUnbounded(t *testing.T) {
+	start := parseTime("2000-01-01T00:00:00Z")
+	i := Interval{Start: start, Bounds: EndUnbounded}
+	r := RepeatingInterval{Repetitions: -1, Start: start, Period: Period{Days: 1}}
+	walkers := map[string]func(ctx context.Context) (<-chan Interval, error){
+		"WalkContext": func(ctx context.Context) (<-chan Interval, error) { return i.WalkContext(ctx, Day) },
+		"WalkAnchoredContext": func(ctx context.Context) (<-chan Interval, error) {
+			return i.WalkAnchoredContext(ctx, Day, OverflowClamp)
+		},
+		"WalkPeriodContext":             func(ctx context.Context) (<-chan Interval, error) { return i.WalkPeriodContext(ctx, Period{Days: 1}) },
+		"RepeatingInterval.WalkContext": r.WalkContext,
+	}
+	for name, walk := range walkers {
+		ctx, cancel := context.WithCancel(context.Background())
+		ch, err := walk(ctx)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", name, err)
+			cancel()
+			continue
+		}
+		if first := <-ch; !first.Start.Equal(start) {
+			t.Errorf("%s: unexpected first Interval: %v", name, first)
+		}
+		cancel()
+		// the channel is closed, at most after one more Interval
+		n := 0
+		for range ch {
+			n++
+		}
+		if n > 1 {
+			t.Errorf("%s: %d Intervals after cancelling", name, n)
+		}
+	}
+
+	if _, err := i.WalkAnchored(Day, OverflowClamp); err == nil {
+		t.Error("Expected error from WalkAnchored with an unbounded End")
+	}
+	if _, err := i.WalkPeriod(Period{Days: 1}); err == nil {
+		t.Error("Expected error from WalkPeriod with an unbounded End")
+	}
+	if _, err := r.Walk(); err == nil {
+		t.Error("Expected error from RepeatingInterval.Walk with unbounded repetitions")
+	}
+}
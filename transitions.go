@@ -0,0 +1,93 @@
+package timewalker
+
+import (
+	"fmt"
+	"time"
+)
+
+// Transition represents a change of UTC offset, or of zone abbreviation, in a time.Location
+type Transition struct {
+	// At is the first instant with the new offset, in the Location
+	At time.Time
+	// OldOffset and NewOffset are in seconds east of UTC, as returned by time.Time.Zone
+	OldOffset int
+	NewOffset int
+	// OldName and NewName are the zone abbreviations, e.g. EST and EDT
+	OldName string
+	NewName string
+}
+
+// IsGap reports whether clocks spring forward at the Transition, skipping some wall clock times
+func (tr Transition) IsGap() bool {
+	return tr.NewOffset > tr.OldOffset
+}
+
+// IsOverlap reports whether clocks fall back at the Transition, repeating some wall clock times
+func (tr Transition) IsOverlap() bool {
+	return tr.NewOffset < tr.OldOffset
+}
+
+func (tr Transition) String() string {
+	return fmt.Sprintf("%s %s(%+d)->%s(%+d)", tr.At.Format(time.RFC3339), tr.OldName, tr.OldOffset, tr.NewName, tr.NewOffset)
+}
+
+// transitionScanStep is the step used to find the neighbourhood of transitions, before bisecting;
+// we assume a Location never changes offset twice, back and forth, within that step
+const transitionScanStep = 7 * 24 * time.Hour
+
+// Transitions lists the Transitions of loc within the receiver's interval [Start,End), accurate to the second.
+// Instead of stepping through every second, zones are compared a week apart, and each change is then located by bisection on time.Time.Zone.
+func Transitions(loc *time.Location, i Interval) ([]Transition, error) {
+	if i.Bounds&(StartUnbounded|EndUnbounded) != 0 {
+		return nil, fmt.Errorf("cannot list Transitions in an unbounded Interval: %v", i)
+	}
+	var transitions []Transition
+	// bisection finds transitions after lo, so start a second early, for a transition right at Start
+	lo := i.Start.Add(-time.Second).In(loc)
+	for lo.Before(i.End) {
+		hi := lo.Add(transitionScanStep)
+		if hi.After(i.End) {
+			hi = i.End.In(loc)
+		}
+		if tr, ok := bisectTransition(lo, hi); ok {
+			if !tr.At.Before(i.End) {
+				break
+			}
+			if !tr.At.Before(i.Start) {
+				transitions = append(transitions, tr)
+			}
+			// continue from the transition, so none is skipped
+			lo = tr.At
+			continue
+		}
+		lo = hi
+	}
+	return transitions, nil
+}
+
+// bisectTransition finds the first Transition in (lo,hi], if lo and hi are in different zones
+func bisectTransition(lo, hi time.Time) (Transition, bool) {
+	loName, loOffset := lo.Zone()
+	hiName, hiOffset := hi.Zone()
+	if loName == hiName && loOffset == hiOffset {
+		return Transition{}, false
+	}
+	loc := lo.Location()
+	// transitions happen on whole seconds
+	l, h := lo.Unix(), hi.Unix()
+	if hi.Nanosecond() > 0 {
+		h++
+	}
+	for h-l > 1 {
+		mid := l + (h-l)/2
+		name, offset := time.Unix(mid, 0).In(loc).Zone()
+		if name == loName && offset == loOffset {
+			l = mid
+		} else {
+			h = mid
+		}
+	}
+	at := time.Unix(h, 0).In(loc)
+	newName, newOffset := at.Zone()
+	return Transition{At: at, OldOffset: loOffset, NewOffset: newOffset, OldName: loName, NewName: newName}, true
+}
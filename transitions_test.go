@@ -0,0 +1,81 @@
+package timewalker
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestTransitions(t *testing.T) {
+	loc, _ := time.LoadLocation("America/Montreal")
+	var testData = []struct {
+		inp Interval // interval
+		exp []string // expected transitions
+	}{
+		{ // whole year
+			inp: parseIntvl("2008-01-01T00:00:00Z", "2009-01-01T00:00:00Z"),
+			exp: []string{"2008-03-09T03:00:00-04:00 EST(-18000)->EDT(-14400)", "2008-11-02T01:00:00-05:00 EDT(-14400)->EST(-18000)"},
+		}, { // starting at, and ending right after, a transition
+			inp: parseIntvl("2008-03-09T07:00:00Z", "2008-11-02T06:00:01Z"),
+			exp: []string{"2008-03-09T03:00:00-04:00 EST(-18000)->EDT(-14400)", "2008-11-02T01:00:00-05:00 EDT(-14400)->EST(-18000)"},
+		}, { // starting at a transition
+			inp: parseIntvl("2024-03-10T07:00:00Z", "2024-03-11T00:00:00Z"),
+			exp: []string{"2024-03-10T03:00:00-04:00 EST(-18000)->EDT(-14400)"},
+		}, { // starting a second before a transition
+			inp: parseIntvl("2024-03-10T06:59:59Z", "2024-03-11T00:00:00Z"),
+			exp: []string{"2024-03-10T03:00:00-04:00 EST(-18000)->EDT(-14400)"},
+		}, { // starting right after a transition
+			inp: parseIntvl("2024-03-10T07:00:00.5Z", "2024-03-11T00:00:00Z"),
+			exp: nil,
+		}, { // ending right at a transition
+			inp: parseIntvl("2008-03-01T00:00:00Z", "2008-03-09T07:00:00Z"),
+			exp: nil,
+		}, { // ending right after a transition
+			inp: parseIntvl("2008-03-01T00:00:00Z", "2008-03-09T07:00:00.5Z"),
+			exp: []string{"2008-03-09T03:00:00-04:00 EST(-18000)->EDT(-14400)"},
+		}, { // none
+			inp: parseIntvl("2008-04-01T00:00:00Z", "2008-05-01T00:00:00Z"),
+			exp: nil,
+		},
+	}
+	for _, tt := range testData {
+		actual, err := Transitions(loc, tt.inp)
+		if err != nil {
+			t.Errorf("Transitions(%v): unexpected error: %v", tt.inp, err)
+		}
+		if fmt.Sprint(actual) != fmt.Sprint(tt.exp) {
+			t.Errorf("Transitions(%v): \nexp: %v, \nact: %v", tt.inp, tt.exp, actual)
+		}
+	}
+
+	if _, err := Transitions(loc, Interval{Bounds: EndUnbounded}); err == nil {
+		t.Error("Expected error for unbounded Interval")
+	}
+	if tr, _ := Transitions(time.UTC, parseIntvl("2000-01-01T00:00:00Z", "2010-01-01T00:00:00Z")); len(tr) != 0 {
+		t.Errorf("Transitions(UTC): %v", tr)
+	}
+}
+
+// Transitions finds the same DST boundaries as Example_daylightSavingsBoundaries, to the second
+func ExampleTransitions() {
+	loc, _ := time.LoadLocation("America/Montreal")
+	i := Interval{
+		Start: time.Date(2006, time.January, 1, 0, 0, 0, 0, loc),
+		End:   time.Date(2009, time.January, 1, 0, 0, 0, 0, loc),
+	}
+	transitions, _ := Transitions(loc, i)
+	for _, tr := range transitions {
+		kind := "overlap"
+		if tr.IsGap() {
+			kind = "gap"
+		}
+		fmt.Printf("%v %s->%s %s of %v\n", tr.At, tr.OldName, tr.NewName, kind, time.Duration(tr.NewOffset-tr.OldOffset)*time.Second)
+	}
+	// Output:
+	// 2006-04-02 03:00:00 -0400 EDT EST->EDT gap of 1h0m0s
+	// 2006-10-29 01:00:00 -0500 EST EDT->EST overlap of -1h0m0s
+	// 2007-03-11 03:00:00 -0400 EDT EST->EDT gap of 1h0m0s
+	// 2007-11-04 01:00:00 -0500 EST EDT->EST overlap of -1h0m0s
+	// 2008-03-09 03:00:00 -0400 EDT EST->EDT gap of 1h0m0s
+	// 2008-11-02 01:00:00 -0500 EST EDT->EST overlap of -1h0m0s
+}
@@ -0,0 +1,13 @@
+//go:build !timewalker_notzembed && !timewalker_no_america
+// +build !timewalker_notzembed,!timewalker_no_america
+
+package tzif
+
+import "embed"
+
+//go:embed zoneinfo/America
+var americaZones embed.FS
+
+func init() {
+	registerFS(americaZones)
+}
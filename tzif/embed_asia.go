@@ -0,0 +1,13 @@
+//go:build !timewalker_notzembed && !timewalker_no_asia
+// +build !timewalker_notzembed,!timewalker_no_asia
+
+package tzif
+
+import "embed"
+
+//go:embed zoneinfo/Asia
+var asiaZones embed.FS
+
+func init() {
+	registerFS(asiaZones)
+}
@@ -0,0 +1,13 @@
+//go:build !timewalker_notzembed && !timewalker_no_australia
+// +build !timewalker_notzembed,!timewalker_no_australia
+
+package tzif
+
+import "embed"
+
+//go:embed zoneinfo/Australia
+var australiaZones embed.FS
+
+func init() {
+	registerFS(australiaZones)
+}
@@ -0,0 +1,13 @@
+//go:build !timewalker_notzembed && !timewalker_no_etc
+// +build !timewalker_notzembed,!timewalker_no_etc
+
+package tzif
+
+import "embed"
+
+//go:embed zoneinfo/Etc
+var etcZones embed.FS
+
+func init() {
+	registerFS(etcZones)
+}
@@ -0,0 +1,13 @@
+//go:build !timewalker_notzembed && !timewalker_no_europe
+// +build !timewalker_notzembed,!timewalker_no_europe
+
+package tzif
+
+import "embed"
+
+//go:embed zoneinfo/Europe
+var europeZones embed.FS
+
+func init() {
+	registerFS(europeZones)
+}
@@ -0,0 +1,25 @@
+package tzif_test
+
+import (
+	"fmt"
+
+	"github.com/daneroo/timewalker"
+	"github.com/daneroo/timewalker/tzif"
+)
+
+// Walking days in a named zone, with the embedded zone database, is the same on any machine
+func ExampleLoadLocation() {
+	loc, _ := tzif.LoadLocation("America/Montreal")
+	i := timewalker.DateInterval{
+		Start: timewalker.Date{Year: 2008, Month: 11, Day: 1},
+		End:   timewalker.Date{Year: 2008, Month: 11, Day: 4},
+	}.In(loc)
+	days, _ := i.Walk(timewalker.Day)
+	for day := range days {
+		fmt.Printf("%v has %.0f hours\n", day.Start, day.End.Sub(day.Start).Hours())
+	}
+	// Output:
+	// 2008-11-01 00:00:00 -0400 EDT has 24 hours
+	// 2008-11-02 00:00:00 -0400 EDT has 25 hours
+	// 2008-11-03 00:00:00 -0500 EST has 24 hours
+}
@@ -0,0 +1,359 @@
+package tzif
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Rule is a parsed POSIX TZ string, e.g. EST5EDT,M3.2.0,M11.1.0, as found in the footer of TZif files.
+// Offsets are in seconds east of UTC, i.e. with the opposite sign of the TZ string.
+type Rule struct {
+	StdName   string
+	StdOffset int
+	// DSTName is empty when there is no daylight savings time
+	DSTName   string
+	DSTOffset int
+	// Start and End are when daylight savings time starts and ends, in local standard and daylight time respectively
+	Start RuleDate
+	End   RuleDate
+}
+
+// RuleDate is a date rule of a POSIX TZ string, with the time of day
+type RuleDate struct {
+	// Kind is 'J' for Jn (1-365, February 29 is never counted), 'D' for n (0-365), or 'M' for Mm.w.d
+	Kind byte
+	// Day is n for the J and D kinds, and the weekday d (0 is Sunday) for the M kind
+	Day int
+	// Week (1-5, 5 meaning the last) and Month (1-12) are only used by the M kind
+	Week  int
+	Month int
+	// Time is the local time of day, in seconds, which may be negative or exceed 24 hours in version 3 TZ strings
+	Time int
+}
+
+// POSIXLocation returns a *time.Location with the given name, behaving as described by the POSIX TZ string, e.g. CET-1CEST,M3.5.0,M10.5.0/3
+func POSIXLocation(name, tz string) (*time.Location, error) {
+	r, err := ParsePOSIX(tz)
+	if err != nil {
+		return nil, err
+	}
+	f := &File{Version: 3, Types: []ZoneType{{Offset: r.StdOffset, Name: r.StdName}}, Footer: tz}
+	return f.Location(name)
+}
+
+// ParsePOSIX parses a POSIX TZ string: std offset [dst [offset] [,start[/time],end[/time]]], with the version 3 extensions of RFC 8536.
+// When dst has no offset, it is one hour ahead of std, and when it has no rules, the US rules M3.2.0,M11.1.0 are used.
+func ParsePOSIX(tz string) (*Rule, error) {
+	p := &posixParser{s: tz}
+	r := &Rule{}
+	var err error
+	if r.StdName, err = p.name(); err != nil {
+		return nil, err
+	}
+	if r.StdOffset, err = p.offset(); err != nil {
+		return nil, err
+	}
+	if p.done() {
+		return r, nil
+	}
+
+	if r.DSTName, err = p.name(); err != nil {
+		return nil, err
+	}
+	r.DSTOffset = r.StdOffset + 3600
+	if !p.done() && p.peek() != ',' {
+		if r.DSTOffset, err = p.offset(); err != nil {
+			return nil, err
+		}
+	}
+	if p.done() {
+		r.Start = RuleDate{Kind: 'M', Month: 3, Week: 2, Day: 0, Time: 2 * 3600}
+		r.End = RuleDate{Kind: 'M', Month: 11, Week: 1, Day: 0, Time: 2 * 3600}
+		return r, nil
+	}
+	for _, d := range []*RuleDate{&r.Start, &r.End} {
+		if !p.consume(',') {
+			return nil, p.errorf("expected ','")
+		}
+		if *d, err = p.date(); err != nil {
+			return nil, err
+		}
+	}
+	if !p.done() {
+		return nil, p.errorf("unexpected %q", p.s[p.pos:])
+	}
+	return r, nil
+}
+
+// String returns the POSIX TZ string of the Rule, in a canonical form
+func (r *Rule) String() string {
+	s := quoteName(r.StdName) + formatClock(-r.StdOffset)
+	if r.DSTName == "" {
+		return s
+	}
+	s += quoteName(r.DSTName)
+	if r.DSTOffset != r.StdOffset+3600 {
+		s += formatClock(-r.DSTOffset)
+	}
+	return s + "," + r.Start.String() + "," + r.End.String()
+}
+
+// String returns the date rule in the POSIX TZ string format, e.g. M3.2.0, or M10.5.0/3 when the time is not 02:00
+func (d RuleDate) String() string {
+	var s string
+	switch d.Kind {
+	case 'J':
+		s = "J" + strconv.Itoa(d.Day)
+	case 'M':
+		s = fmt.Sprintf("M%d.%d.%d", d.Month, d.Week, d.Day)
+	default:
+		s = strconv.Itoa(d.Day)
+	}
+	if d.Time != 2*3600 {
+		s += "/" + formatClock(d.Time)
+	}
+	return s
+}
+
+// Lookup returns the zone in effect at the given time, in seconds since the Unix epoch
+func (r *Rule) Lookup(when int64) (name string, offset int, isDST bool) {
+	if r.DSTName == "" {
+		return r.StdName, r.StdOffset, false
+	}
+	// the latest transition before when, among those of the surrounding years
+	year := time.Unix(when+int64(r.StdOffset), 0).UTC().Year()
+	var latest int64
+	dst, found := false, false
+	for y := year - 1; y <= year+1; y++ {
+		start := r.Start.unix(y) - int64(r.StdOffset)
+		end := r.End.unix(y) - int64(r.DSTOffset)
+		for _, tr := range []struct {
+			when int64
+			dst  bool
+		}{{start, true}, {end, false}} {
+			if tr.when <= when && (!found || tr.when > latest) {
+				latest, dst, found = tr.when, tr.dst, true
+			}
+		}
+	}
+	if dst {
+		return r.DSTName, r.DSTOffset, true
+	}
+	return r.StdName, r.StdOffset, false
+}
+
+// unix returns the local time of the rule in the given year, as seconds since the Unix epoch, as if it were UTC
+func (d RuleDate) unix(year int) int64 {
+	var t time.Time
+	switch d.Kind {
+	case 'J':
+		// February 29 is never counted
+		t = time.Date(year, time.January, d.Day, 0, 0, 0, 0, time.UTC)
+		if isLeap(year) && d.Day >= 60 {
+			t = t.AddDate(0, 0, 1)
+		}
+	case 'M':
+		first := time.Date(year, time.Month(d.Month), 1, 0, 0, 0, 0, time.UTC)
+		day := 1 + (d.Day-int(first.Weekday())+7)%7 + (d.Week-1)*7
+		if last := time.Date(year, time.Month(d.Month)+1, 0, 0, 0, 0, 0, time.UTC).Day(); day > last {
+			day -= 7
+		}
+		t = time.Date(year, time.Month(d.Month), day, 0, 0, 0, 0, time.UTC)
+	default:
+		t = time.Date(year, time.January, 1+d.Day, 0, 0, 0, 0, time.UTC)
+	}
+	return t.Unix() + int64(d.Time)
+}
+
+func isLeap(year int) bool {
+	return year%4 == 0 && (year%100 != 0 || year%400 == 0)
+}
+
+// posixParser holds the state of ParsePOSIX
+type posixParser struct {
+	s   string
+	pos int
+}
+
+func (p *posixParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("invalid POSIX TZ string %q: %s at position %d", p.s, fmt.Sprintf(format, args...), p.pos)
+}
+
+func (p *posixParser) done() bool {
+	return p.pos >= len(p.s)
+}
+
+func (p *posixParser) peek() byte {
+	return p.s[p.pos]
+}
+
+func (p *posixParser) consume(c byte) bool {
+	if !p.done() && p.peek() == c {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+// name parses a zone abbreviation: at least 3 letters, or <...> with letters, digits, '+' and '-'
+func (p *posixParser) name() (string, error) {
+	start := p.pos
+	if p.consume('<') {
+		for !p.done() && p.peek() != '>' {
+			c := p.peek()
+			if !isAlpha(c) && !isDigit(c) && c != '+' && c != '-' {
+				return "", p.errorf("unexpected %q in quoted name", c)
+			}
+			p.pos++
+		}
+		name := p.s[start+1 : p.pos]
+		if !p.consume('>') {
+			return "", p.errorf("unterminated quoted name")
+		}
+		if len(name) < 3 {
+			return "", p.errorf("name %q is shorter than 3 characters", name)
+		}
+		return name, nil
+	}
+	for !p.done() && isAlpha(p.peek()) {
+		p.pos++
+	}
+	if name := p.s[start:p.pos]; len(name) >= 3 {
+		return name, nil
+	}
+	return "", p.errorf("expected a name of at least 3 letters")
+}
+
+// offset parses a zone offset, [+-]hh[:mm[:ss]] west of UTC, and returns it east of UTC
+func (p *posixParser) offset() (int, error) {
+	sign := 1
+	if p.consume('-') {
+		sign = -1
+	} else {
+		p.consume('+')
+	}
+	secs, err := p.clock(24)
+	return -sign * secs, err
+}
+
+// clock parses hh[:mm[:ss]] with hours up to max, as seconds
+func (p *posixParser) clock(max int) (int, error) {
+	secs := 0
+	for i, limit := range []int{max, 59, 59} {
+		if i > 0 && !p.consume(':') {
+			break
+		}
+		start := p.pos
+		for !p.done() && isDigit(p.peek()) && p.pos-start < 3 {
+			p.pos++
+		}
+		if start == p.pos {
+			return 0, p.errorf("expected digit")
+		}
+		v, _ := strconv.Atoi(p.s[start:p.pos])
+		if v > limit {
+			p.pos = start
+			return 0, p.errorf("%d out of range", v)
+		}
+		secs += v * []int{3600, 60, 1}[i]
+	}
+	return secs, nil
+}
+
+// date parses a date rule, with its optional /time
+func (p *posixParser) date() (RuleDate, error) {
+	d := RuleDate{Kind: 'D', Time: 2 * 3600}
+	var err error
+	switch {
+	case p.consume('J'):
+		d.Kind = 'J'
+		if d.Day, err = p.number(1, 365); err != nil {
+			return d, err
+		}
+	case p.consume('M'):
+		d.Kind = 'M'
+		if d.Month, err = p.number(1, 12); err != nil {
+			return d, err
+		}
+		if !p.consume('.') {
+			return d, p.errorf("expected '.'")
+		}
+		if d.Week, err = p.number(1, 5); err != nil {
+			return d, err
+		}
+		if !p.consume('.') {
+			return d, p.errorf("expected '.'")
+		}
+		if d.Day, err = p.number(0, 6); err != nil {
+			return d, err
+		}
+	default:
+		if d.Day, err = p.number(0, 365); err != nil {
+			return d, err
+		}
+	}
+	if p.consume('/') {
+		// version 3 allows -167 to 167 hours
+		sign := 1
+		if p.consume('-') {
+			sign = -1
+		} else {
+			p.consume('+')
+		}
+		secs, err := p.clock(167)
+		if err != nil {
+			return d, err
+		}
+		d.Time = sign * secs
+	}
+	return d, nil
+}
+
+// number parses a decimal number within [min,max]
+func (p *posixParser) number(min, max int) (int, error) {
+	start := p.pos
+	for !p.done() && isDigit(p.peek()) {
+		p.pos++
+	}
+	v, err := strconv.Atoi(p.s[start:p.pos])
+	if err != nil || v < min || v > max {
+		p.pos = start
+		return 0, p.errorf("expected a number in [%d,%d]", min, max)
+	}
+	return v, nil
+}
+
+func isAlpha(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// quoteName quotes a zone abbreviation when it is not only letters, e.g. <+1030>
+func quoteName(name string) string {
+	for i := 0; i < len(name); i++ {
+		if !isAlpha(name[i]) {
+			return "<" + name + ">"
+		}
+	}
+	return name
+}
+
+// formatClock formats seconds as [-]h[:mm[:ss]]
+func formatClock(secs int) string {
+	sign := ""
+	if secs < 0 {
+		sign, secs = "-", -secs
+	}
+	s := sign + strconv.Itoa(secs/3600)
+	if m, sec := secs/60%60, secs%60; m != 0 || sec != 0 {
+		s += fmt.Sprintf(":%02d", m)
+		if sec != 0 {
+			s += fmt.Sprintf(":%02d", sec)
+		}
+	}
+	return s
+}
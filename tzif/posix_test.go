@@ -0,0 +1,100 @@
+package tzif
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestParsePOSIX(t *testing.T) {
+	var testData = []struct {
+		inp string // input
+		exp string // expected canonical String()
+	}{
+		{"UTC0", "UTC0"},
+		{"JST-9", "JST-9"},
+		{"IST-5:30", "IST-5:30"},
+		{"<-03>3", "<-03>3"},
+		{"EST5EDT", "EST5EDT,M3.2.0,M11.1.0"},
+		{"EST+5EDT4,M3.2.0/2,M11.1.0/02:00:00", "EST5EDT,M3.2.0,M11.1.0"},
+		{"AEST-10AEDT,M10.1.0,M4.1.0/3", "AEST-10AEDT,M10.1.0,M4.1.0/3"},
+		{"<+1030>-10:30<+11>-11,M10.1.0,M4.1.0", "<+1030>-10:30<+11>-11,M10.1.0,M4.1.0"},
+		{"<-03>3<-02>,M3.5.0/-2,M10.5.0/-1", "<-03>3<-02>,M3.5.0/-2,M10.5.0/-1"},
+		{"XXX3EDT4,J60/1:30:15,300/100", "XXX3EDT4,J60/1:30:15,300/100"},
+	}
+	for _, tt := range testData {
+		r, err := ParsePOSIX(tt.inp)
+		if err != nil {
+			t.Errorf("ParsePOSIX(%q): unexpected error: %v", tt.inp, err)
+			continue
+		}
+		if r.String() != tt.exp {
+			t.Errorf("ParsePOSIX(%q): \nexp: %s, \nact: %s", tt.inp, tt.exp, r)
+		}
+	}
+}
+
+func TestParsePOSIXErrors(t *testing.T) {
+	var testData = []struct {
+		inp string // input
+		exp string // expected error
+	}{
+		{"", `invalid POSIX TZ string "": expected a name of at least 3 letters at position 0`},
+		{"ES5", `invalid POSIX TZ string "ES5": expected a name of at least 3 letters at position 2`},
+		{"EST", `invalid POSIX TZ string "EST": expected digit at position 3`},
+		{"EST25", `invalid POSIX TZ string "EST25": 25 out of range at position 3`},
+		{"EST5:60", `invalid POSIX TZ string "EST5:60": 60 out of range at position 5`},
+		{"<-03", `invalid POSIX TZ string "<-03": unterminated quoted name at position 4`},
+		{"<+1>1", `invalid POSIX TZ string "<+1>1": name "+1" is shorter than 3 characters at position 4`},
+		{"EST5EDT,M3.2.0", `invalid POSIX TZ string "EST5EDT,M3.2.0": expected ',' at position 14`},
+		{"EST5EDT,M13.2.0,M11.1.0", `invalid POSIX TZ string "EST5EDT,M13.2.0,M11.1.0": expected a number in [1,12] at position 9`},
+		{"EST5EDT,M3.2.7,M11.1.0", `invalid POSIX TZ string "EST5EDT,M3.2.7,M11.1.0": expected a number in [0,6] at position 13`},
+		{"EST5EDT,J0,J365", `invalid POSIX TZ string "EST5EDT,J0,J365": expected a number in [1,365] at position 9`},
+		{"EST5EDT,M3.2.0/168,M11.1.0", `invalid POSIX TZ string "EST5EDT,M3.2.0/168,M11.1.0": 168 out of range at position 15`},
+		{"EST5EDT,M3.2.0,M11.1.0x", `invalid POSIX TZ string "EST5EDT,M3.2.0,M11.1.0x": unexpected "x" at position 22`},
+	}
+	for _, tt := range testData {
+		_, err := ParsePOSIX(tt.inp)
+		if err == nil || err.Error() != tt.exp {
+			t.Errorf("ParsePOSIX(%q): \nexp: %v, \nact: %v", tt.inp, tt.exp, err)
+		}
+	}
+}
+
+// Rule.Lookup agrees with the Location built from the same TZ string
+func TestRuleLookup(t *testing.T) {
+	for _, tz := range []string{
+		"EST5EDT,M3.2.0,M11.1.0",
+		"GMT0BST,M3.5.0/1,M10.5.0",
+		"AEST-10AEDT,M10.1.0,M4.1.0/3",
+		"<+1030>-10:30<+11>-11,M10.1.0,M4.1.0",
+		"<-03>3<-02>,M3.5.0/-2,M10.5.0/-1",
+		"XXX3EDT4,J60/1:30:15,300/100",
+		"JST-9",
+	} {
+		r, _ := ParsePOSIX(tz)
+		loc, err := POSIXLocation(tz, tz)
+		if err != nil {
+			t.Errorf("POSIXLocation(%q): unexpected error: %v", tz, err)
+			continue
+		}
+		for _, inst := range sampleTimes(1999, 2005) {
+			name, offset, _ := r.Lookup(inst.Unix())
+			en, eo := inst.In(loc).Zone()
+			if name != en || offset != eo {
+				t.Errorf("%q.Lookup(%v): exp: %s%+d act: %s%+d", tz, inst, en, eo, name, offset)
+				break
+			}
+		}
+	}
+}
+
+func ExamplePOSIXLocation() {
+	loc, _ := POSIXLocation("Europe/Paris", "CET-1CEST,M3.5.0,M10.5.0/3")
+	for _, month := range []time.Month{time.January, time.July} {
+		fmt.Println(time.Date(2030, month, 1, 12, 0, 0, 0, loc))
+	}
+	// Output:
+	// 2030-01-01 12:00:00 +0100 CET
+	// 2030-07-01 12:00:00 +0200 CEST
+}
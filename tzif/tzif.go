@@ -0,0 +1,312 @@
+// Package tzif parses time zone information files (TZif, RFC 8536, versions 1 to 3) and POSIX TZ strings into *time.Location,
+// and exposes their raw transition tables. A subset of the IANA time zone database is embedded, so walking in a named zone
+// is reproducible on any machine, independently of the host's zone database, see LoadLocation.
+package tzif
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"time"
+)
+
+// File is the content of a TZif file
+type File struct {
+	// Version is 1, 2 or 3; version 1 files only have 32-bit transition times and no Footer
+	Version int
+	// Transitions are sorted by time
+	Transitions []Transition
+	// Types are the local time types the Transitions refer to; before the first Transition, the first type is in effect
+	Types []ZoneType
+	// LeapSeconds are the leap second corrections, usually empty
+	LeapSeconds []LeapSecond
+	// Footer is the POSIX TZ string describing the local time types after the last Transition, e.g. EST5EDT,M3.2.0,M11.1.0
+	Footer string
+}
+
+// Transition is a change of local time type
+type Transition struct {
+	// When is the time of the Transition, in seconds since the Unix epoch
+	When int64
+	// Type is the index of the local time type in effect from When
+	Type int
+}
+
+// ZoneType is a local time type
+type ZoneType struct {
+	// Offset is in seconds east of UTC
+	Offset int
+	// IsDST reports whether this is daylight savings time
+	IsDST bool
+	// Name is the zone abbreviation, e.g. EDT
+	Name string
+	// IsStd and IsUT qualify the transition times of the POSIX TZ string rules, and are rarely used
+	IsStd bool
+	IsUT  bool
+}
+
+// LeapSecond is a leap second correction
+type LeapSecond struct {
+	// When is the time at which the correction applies, in seconds since the Unix epoch
+	When int64
+	// Correction is the total correction from When on
+	Correction int64
+}
+
+// magic starts every TZif file
+const magic = "TZif"
+
+// header holds the counts of a TZif header
+type header struct {
+	version                                               byte
+	isutcnt, isstdcnt, leapcnt, timecnt, typecnt, charcnt int
+}
+
+// Parse parses a TZif file; for version 2 and later, the 64-bit data block and the Footer are used
+func Parse(data []byte) (*File, error) {
+	r := &reader{data: data}
+	h, err := r.header()
+	if err != nil {
+		return nil, err
+	}
+	f := &File{Version: 1}
+	if h.version != 0 {
+		f.Version = int(h.version - '0')
+	}
+	if h.version == 0 {
+		if err := r.block(h, 4, f); err != nil {
+			return nil, err
+		}
+		return f, nil
+	}
+
+	// skip the version 1 data block
+	if !r.skip(h.timecnt*5 + h.typecnt*6 + h.charcnt + h.leapcnt*8 + h.isstdcnt + h.isutcnt) {
+		return nil, fmt.Errorf("invalid TZif data: truncated version 1 data block")
+	}
+	if h, err = r.header(); err != nil {
+		return nil, err
+	}
+	if err := r.block(h, 8, f); err != nil {
+		return nil, err
+	}
+
+	// the footer is a POSIX TZ string between newlines
+	footer := r.data[r.pos:]
+	if len(footer) < 2 || footer[0] != '\n' {
+		return nil, fmt.Errorf("invalid TZif data: missing footer")
+	}
+	end := bytes.IndexByte(footer[1:], '\n')
+	if end < 0 {
+		return nil, fmt.Errorf("invalid TZif data: unterminated footer")
+	}
+	f.Footer = string(footer[1 : end+1])
+	if f.Footer != "" {
+		if _, err := ParsePOSIX(f.Footer); err != nil {
+			return nil, fmt.Errorf("invalid TZif data: %v", err)
+		}
+	}
+	return f, nil
+}
+
+// Location returns a *time.Location with the given name, behaving as described by the File
+func (f *File) Location(name string) (*time.Location, error) {
+	return time.LoadLocationFromTZData(name, f.Encode())
+}
+
+// Encode returns the File in the TZif format, as a version 2 file, or version 3 when the Version is 3
+func (f *File) Encode() []byte {
+	version := byte('2')
+	if f.Version >= 3 {
+		version = '3'
+	}
+
+	// abbreviations, shared between types
+	var chars []byte
+	nameIndex := map[string]int{}
+	for _, t := range f.Types {
+		if _, ok := nameIndex[t.Name]; !ok {
+			nameIndex[t.Name] = len(chars)
+			chars = append(append(chars, t.Name...), 0)
+		}
+	}
+
+	var buf bytes.Buffer
+	for _, size := range []int{4, 8} {
+		// version 1 data block, with the transitions which fit in 32 bits
+		transitions, leaps := f.Transitions, f.LeapSeconds
+		if size == 4 {
+			transitions, leaps = nil, nil
+			for _, tr := range f.Transitions {
+				if fits32(tr.When) {
+					transitions = append(transitions, tr)
+				}
+			}
+			for _, ls := range f.LeapSeconds {
+				if fits32(ls.When) {
+					leaps = append(leaps, ls)
+				}
+			}
+		}
+		buf.WriteString(magic)
+		buf.WriteByte(version)
+		buf.Write(make([]byte, 15))
+		for _, n := range []int{len(f.Types), len(f.Types), len(leaps), len(transitions), len(f.Types), len(chars)} {
+			writeInt(&buf, 4, int64(n))
+		}
+		for _, tr := range transitions {
+			writeInt(&buf, size, tr.When)
+		}
+		for _, tr := range transitions {
+			buf.WriteByte(byte(tr.Type))
+		}
+		for _, t := range f.Types {
+			writeInt(&buf, 4, int64(t.Offset))
+			buf.WriteByte(boolByte(t.IsDST))
+			buf.WriteByte(byte(nameIndex[t.Name]))
+		}
+		buf.Write(chars)
+		for _, ls := range leaps {
+			writeInt(&buf, size, ls.When)
+			writeInt(&buf, 4, ls.Correction)
+		}
+		for _, t := range f.Types {
+			buf.WriteByte(boolByte(t.IsStd))
+		}
+		for _, t := range f.Types {
+			buf.WriteByte(boolByte(t.IsUT))
+		}
+	}
+	buf.WriteByte('\n')
+	buf.WriteString(f.Footer)
+	buf.WriteByte('\n')
+	return buf.Bytes()
+}
+
+// reader reads the big-endian binary data of a TZif file
+type reader struct {
+	data []byte
+	pos  int
+}
+
+// header reads a TZif header
+func (r *reader) header() (header, error) {
+	var h header
+	if len(r.data)-r.pos < 44 || string(r.data[r.pos:r.pos+4]) != magic {
+		return h, fmt.Errorf("invalid TZif data: bad magic")
+	}
+	h.version = r.data[r.pos+4]
+	if h.version != 0 && (h.version < '2' || h.version > '4') {
+		return h, fmt.Errorf("invalid TZif data: unsupported version %q", h.version)
+	}
+	r.pos += 20
+	counts := []*int{&h.isutcnt, &h.isstdcnt, &h.leapcnt, &h.timecnt, &h.typecnt, &h.charcnt}
+	for _, c := range counts {
+		*c = int(binary.BigEndian.Uint32(r.data[r.pos:]))
+		r.pos += 4
+	}
+	if h.typecnt == 0 || h.charcnt == 0 ||
+		(h.isutcnt != 0 && h.isutcnt != h.typecnt) || (h.isstdcnt != 0 && h.isstdcnt != h.typecnt) {
+		return h, fmt.Errorf("invalid TZif data: inconsistent header counts")
+	}
+	return h, nil
+}
+
+// block reads a TZif data block, with times of the given size in bytes, into f
+func (r *reader) block(h header, size int, f *File) error {
+	if len(r.data)-r.pos < h.timecnt*(size+1)+h.typecnt*6+h.charcnt+h.leapcnt*(size+4)+h.isstdcnt+h.isutcnt {
+		return fmt.Errorf("invalid TZif data: truncated data block")
+	}
+	f.Transitions = make([]Transition, h.timecnt)
+	for i := range f.Transitions {
+		f.Transitions[i].When = r.int(size)
+		if i > 0 && f.Transitions[i].When <= f.Transitions[i-1].When {
+			return fmt.Errorf("invalid TZif data: unsorted transitions")
+		}
+	}
+	for i := range f.Transitions {
+		f.Transitions[i].Type = int(r.data[r.pos])
+		r.pos++
+		if f.Transitions[i].Type >= h.typecnt {
+			return fmt.Errorf("invalid TZif data: transition type out of range")
+		}
+	}
+	f.Types = make([]ZoneType, h.typecnt)
+	nameIndices := make([]int, h.typecnt)
+	for i := range f.Types {
+		f.Types[i].Offset = int(r.int(4))
+		f.Types[i].IsDST = r.data[r.pos] != 0
+		nameIndices[i] = int(r.data[r.pos+1])
+		r.pos += 2
+	}
+	chars := r.data[r.pos : r.pos+h.charcnt]
+	r.pos += h.charcnt
+	for i, idx := range nameIndices {
+		if idx >= len(chars) {
+			return fmt.Errorf("invalid TZif data: abbreviation index out of range")
+		}
+		end := bytes.IndexByte(chars[idx:], 0)
+		if end < 0 {
+			return fmt.Errorf("invalid TZif data: unterminated abbreviation")
+		}
+		f.Types[i].Name = string(chars[idx : idx+end])
+	}
+	f.LeapSeconds = nil
+	for i := 0; i < h.leapcnt; i++ {
+		when := r.int(size)
+		f.LeapSeconds = append(f.LeapSeconds, LeapSecond{When: when, Correction: r.int(4)})
+	}
+	for i := 0; i < h.isstdcnt; i++ {
+		f.Types[i].IsStd = r.data[r.pos] != 0
+		r.pos++
+	}
+	for i := 0; i < h.isutcnt; i++ {
+		f.Types[i].IsUT = r.data[r.pos] != 0
+		r.pos++
+	}
+	return nil
+}
+
+// int reads a signed big-endian integer of the given size in bytes, 4 or 8
+func (r *reader) int(size int) int64 {
+	var v int64
+	if size == 4 {
+		v = int64(int32(binary.BigEndian.Uint32(r.data[r.pos:])))
+	} else {
+		v = int64(binary.BigEndian.Uint64(r.data[r.pos:]))
+	}
+	r.pos += size
+	return v
+}
+
+// skip advances by n bytes, reporting whether there were enough
+func (r *reader) skip(n int) bool {
+	if len(r.data)-r.pos < n {
+		return false
+	}
+	r.pos += n
+	return true
+}
+
+// writeInt writes a signed big-endian integer of the given size in bytes, 4 or 8
+func writeInt(buf *bytes.Buffer, size int, v int64) {
+	var b [8]byte
+	if size == 4 {
+		binary.BigEndian.PutUint32(b[:], uint32(int32(v)))
+	} else {
+		binary.BigEndian.PutUint64(b[:], uint64(v))
+	}
+	buf.Write(b[:size])
+}
+
+// fits32 reports whether a time fits in a version 1 data block
+func fits32(when int64) bool {
+	return when >= -1<<31 && when < 1<<31
+}
+
+func boolByte(b bool) byte {
+	if b {
+		return 1
+	}
+	return 0
+}
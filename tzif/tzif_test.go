@@ -0,0 +1,130 @@
+package tzif
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+// sampleTimes returns instants every few hours over the years, to compare Locations
+func sampleTimes(from, to int) []time.Time {
+	var times []time.Time
+	for t := time.Date(from, time.January, 1, 0, 0, 0, 0, time.UTC); t.Year() < to; t = t.Add(7 * time.Hour) {
+		times = append(times, t)
+	}
+	return times
+}
+
+func sameZones(t *testing.T, exp, act *time.Location, times []time.Time) {
+	t.Helper()
+	for _, inst := range times {
+		en, eo := inst.In(exp).Zone()
+		an, ao := inst.In(act).Zone()
+		if en != an || eo != ao {
+			t.Errorf("%v: exp: %s%+d act: %s%+d", inst, en, eo, an, ao)
+			return
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	f, err := Load("America/Montreal")
+	if err != nil {
+		t.Fatalf("Load(America/Montreal): unexpected error: %v", err)
+	}
+	if f.Version < 2 || f.Footer != "EST5EDT,M3.2.0,M11.1.0" || len(f.Transitions) == 0 {
+		t.Errorf("Load(America/Montreal): unexpected content: version %d, footer %q, %d transitions", f.Version, f.Footer, len(f.Transitions))
+	}
+
+	// the 2008 transitions
+	var names []string
+	for _, tr := range f.Transitions {
+		if y := time.Unix(tr.When, 0).UTC().Year(); y == 2008 {
+			names = append(names, f.Types[tr.Type].Name)
+		}
+	}
+	if !reflect.DeepEqual(names, []string{"EDT", "EST"}) {
+		t.Errorf("2008 transitions: %v", names)
+	}
+
+	// the Location from the File behaves as the embedded zone, also after the last transition
+	loc, err := f.Location("America/Montreal")
+	if err != nil {
+		t.Fatalf("Location: unexpected error: %v", err)
+	}
+	embedded, _ := LoadLocation("America/Montreal")
+	sameZones(t, embedded, loc, sampleTimes(1900, 2100))
+}
+
+func TestEncode(t *testing.T) {
+	for _, name := range Names() {
+		f, err := Load(name)
+		if err != nil {
+			t.Errorf("Load(%s): unexpected error: %v", name, err)
+			continue
+		}
+		encoded := f.Encode()
+		rt, err := Parse(encoded)
+		if err != nil {
+			t.Errorf("Parse(Encode(%s)): unexpected error: %v", name, err)
+			continue
+		}
+		if !reflect.DeepEqual(f, rt) {
+			t.Errorf("Parse(Encode(%s)): round trip differs", name)
+		}
+
+		// the version 1 data block on its own is a valid version 1 file
+		v1 := append([]byte{}, encoded...)
+		v1[4] = 0
+		f1, err := Parse(v1)
+		if err != nil {
+			t.Errorf("Parse(v1 %s): unexpected error: %v", name, err)
+			continue
+		}
+		if f1.Version != 1 || f1.Footer != "" || len(f1.Transitions) > len(f.Transitions) || !reflect.DeepEqual(f1.Types, f.Types) {
+			t.Errorf("Parse(v1 %s): unexpected content", name)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	f, _ := Load("Europe/London")
+	data := f.Encode()
+	var testData = []struct {
+		name string
+		data []byte
+	}{
+		{"empty", nil},
+		{"bad magic", append([]byte("TZiF"), data[4:]...)},
+		{"bad version", append([]byte("TZif9"), data[5:]...)},
+		{"truncated header", data[:30]},
+		{"truncated v1 block", data[:100]},
+		{"truncated v2 block", data[:len(data)-200]},
+		{"missing footer", data[:len(data)-len(f.Footer)-2]},
+		{"unterminated footer", data[:len(data)-1]},
+		{"bad footer", append(data[:len(data)-len(f.Footer)-1], "GMT0BST,M13.5.0\n"...)},
+	}
+	for _, tt := range testData {
+		if _, err := Parse(tt.data); err == nil {
+			t.Errorf("Parse(%s): expected an error", tt.name)
+		}
+	}
+}
+
+func TestLoadLocation(t *testing.T) {
+	for _, name := range []string{"America/Montreal", "Europe/London", "Asia/Tokyo", "Australia/Lord_Howe", "Etc/UTC"} {
+		loc, err := LoadLocation(name)
+		if err != nil || loc.String() != name {
+			t.Errorf("LoadLocation(%s): %v, %v", name, loc, err)
+		}
+	}
+	if loc, err := LoadLocation("UTC"); err != nil || loc != time.UTC {
+		t.Errorf("LoadLocation(UTC): %v, %v", loc, err)
+	}
+	if _, err := LoadLocation("Nowhere/Special"); err == nil {
+		t.Error("LoadLocation(Nowhere/Special): expected an error")
+	}
+	if err := Register("Bad/Zone", []byte("garbage")); err == nil {
+		t.Error("Register(Bad/Zone): expected an error")
+	}
+}
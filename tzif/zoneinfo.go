@@ -0,0 +1,87 @@
+package tzif
+
+import (
+	"fmt"
+	"io/fs"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// registry holds the TZif data of the embedded and registered zones, by name
+var registry = struct {
+	sync.RWMutex
+	zones map[string][]byte
+}{zones: map[string][]byte{}}
+
+// Register adds, or replaces, the TZif data of a zone, so it can be loaded by LoadLocation and Load.
+// It is meant to be called from init functions, to complete the embedded zones.
+func Register(name string, data []byte) error {
+	if _, err := Parse(data); err != nil {
+		return fmt.Errorf("cannot register %s: %v", name, err)
+	}
+	registry.Lock()
+	defer registry.Unlock()
+	registry.zones[name] = data
+	return nil
+}
+
+// registerFS registers every file of an embedded zoneinfo tree, named by its path below zoneinfo/
+func registerFS(fsys fs.FS) {
+	err := fs.WalkDir(fsys, "zoneinfo", func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		data, err := fs.ReadFile(fsys, path)
+		if err != nil {
+			return err
+		}
+		return Register(strings.TrimPrefix(path, "zoneinfo/"), data)
+	})
+	if err != nil {
+		panic(err)
+	}
+}
+
+// Names returns the sorted names of the embedded and registered zones
+func Names() []string {
+	registry.RLock()
+	defer registry.RUnlock()
+	names := make([]string, 0, len(registry.zones))
+	for name := range registry.zones {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Load returns the parsed TZif File of an embedded or registered zone
+func Load(name string) (*File, error) {
+	registry.RLock()
+	data, ok := registry.zones[name]
+	registry.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown time zone %s", name)
+	}
+	return Parse(data)
+}
+
+// LoadLocation is like time.LoadLocation, but only uses the embedded and registered zones, never the host's zone database,
+// so it returns the same Location on any machine. As with time.LoadLocation, "" and "UTC" return time.UTC.
+//
+// The embedded zones are a subset of the IANA time zone database (version 2025b), grouped by region,
+// and each region can be left out at build time with a build tag: timewalker_no_america, timewalker_no_europe,
+// timewalker_no_asia, timewalker_no_australia, timewalker_no_etc, or timewalker_notzembed for all of them.
+func LoadLocation(name string) (*time.Location, error) {
+	if name == "" || name == "UTC" {
+		return time.UTC, nil
+	}
+	registry.RLock()
+	data, ok := registry.zones[name]
+	registry.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown time zone %s", name)
+	}
+	return time.LoadLocationFromTZData(name, data)
+}
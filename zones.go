@@ -0,0 +1,67 @@
+package timewalker
+
+import (
+	"fmt"
+	"time"
+)
+
+// In returns the receiver's interval with Start and End in the given Location; like time.Time.In, the instants are unchanged
+func (i Interval) In(loc *time.Location) Interval {
+	i.Start, i.End = i.Start.In(loc), i.End.In(loc)
+	return i
+}
+
+// WalkZones traverses the receiver's interval in steps of the given duration, seen from several Locations at once:
+// each step holds the buckets with the same calendar date in every Location, in the order of locs, e.g. March 7 in Tokyo, London and Montreal.
+// The interval is rounded in each Location, and the steps cover the union of the rounded intervals, so a bucket may fall outside i in some zones.
+func WalkZones(i Interval, d Duration, locs ...*time.Location) (<-chan []Interval, error) {
+	if len(locs) == 0 {
+		return nil, fmt.Errorf("WalkZones needs at least one Location")
+	}
+	if i.Bounds&(StartUnbounded|EndUnbounded) != 0 {
+		return nil, fmt.Errorf("cannot walk zones of an unbounded Interval: %v", i)
+	}
+
+	// the calendar dates covered in any zone
+	var dates DateInterval
+	for k, loc := range locs {
+		ri, err := i.In(loc).Round(d)
+		if err != nil {
+			return nil, err
+		}
+		start, end := DateOf(ri.Start), DateOf(ri.End)
+		if k == 0 || start.Before(dates.Start) {
+			dates.Start = start
+		}
+		if k == 0 || end.After(dates.End) {
+			dates.End = end
+		}
+	}
+	steps, err := dates.Walk(d)
+	if err != nil {
+		return nil, err
+	}
+
+	ch := make(chan []Interval)
+	go func() {
+		for step := range steps {
+			buckets := make([]Interval, len(locs))
+			for k, loc := range locs {
+				buckets[k] = step.In(loc)
+			}
+			ch <- buckets
+		}
+		close(ch)
+	}()
+	return ch, nil
+}
+
+// Intersection returns the instants common to all the intervals, e.g. the hours when it is the same day in several zones; see Interval.Intersect.
+// The intersection of no intervals is unbounded.
+func Intersection(intervals ...Interval) Interval {
+	r := Interval{Bounds: StartUnbounded | EndUnbounded}
+	for _, i := range intervals {
+		r = r.Intersect(i)
+	}
+	return r
+}
@@ -0,0 +1,72 @@
+package timewalker
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestWalkZones(t *testing.T) {
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+	montreal, _ := time.LoadLocation("America/Montreal")
+
+	// a UTC day touches two days in Tokyo, and two in Montreal
+	i := parseIntvl("2008-03-09T00:00:00Z", "2008-03-10T00:00:00Z")
+	ch, err := WalkZones(i, Day, tokyo, montreal)
+	if err != nil {
+		t.Fatalf("WalkZones: unexpected error: %v", err)
+	}
+	var steps [][]Interval
+	for step := range ch {
+		steps = append(steps, step)
+	}
+	if len(steps) != 3 {
+		t.Fatalf("WalkZones: exp 3 steps act: %d", len(steps))
+	}
+	for _, step := range steps {
+		if len(step) != 2 || DateOf(step[0].Start) != DateOf(step[1].Start) ||
+			step[0].Start.Location() != tokyo || step[1].Start.Location() != montreal {
+			t.Errorf("WalkZones: misaligned step: %v", step)
+		}
+	}
+	// Montreal springs forward on March 9
+	if hours := steps[1][1].End.Sub(steps[1][1].Start).Hours(); hours != 23 {
+		t.Errorf("WalkZones: exp 23 hours on 2008-03-09 in Montreal act: %v", hours)
+	}
+
+	if _, err := WalkZones(i, Day); err == nil {
+		t.Error("WalkZones: expected an error without Location")
+	}
+	if _, err := WalkZones(Interval{Bounds: EndUnbounded}, Day, tokyo); err == nil {
+		t.Error("WalkZones: expected an error for an unbounded Interval")
+	}
+}
+
+func TestIntersection(t *testing.T) {
+	if r := Intersection(); r.Bounds != StartUnbounded|EndUnbounded {
+		t.Errorf("Intersection(): exp unbounded act: %v", r)
+	}
+	r := Intersection(
+		parseIntvl("2001-01-01T00:00:00Z", "2001-01-03T00:00:00Z"),
+		parseIntvl("2001-01-02T00:00:00Z", "2001-01-05T00:00:00Z"),
+		parseIntvl("2000-01-01T00:00:00Z", "2001-01-02T12:00:00Z"),
+	)
+	if exp := parseIntvl("2001-01-02T00:00:00Z", "2001-01-02T12:00:00Z"); r != exp {
+		t.Errorf("Intersection: \nexp: %v, \nact: %v", exp, r)
+	}
+}
+
+func ExampleWalkZones() {
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+	london, _ := time.LoadLocation("Europe/London")
+	montreal, _ := time.LoadLocation("America/Montreal")
+	i := parseIntvl("2024-03-07T12:00:00Z", "2024-03-08T12:00:00Z")
+	ch, _ := WalkZones(i, Day, tokyo, london, montreal)
+	for buckets := range ch {
+		common := Intersection(buckets...).In(time.UTC)
+		fmt.Printf("%s: same day everywhere during %v\n", DateOf(buckets[0].Start), common)
+	}
+	// Output:
+	// 2024-03-07: same day everywhere during [2024-03-07T05:00:00Z, 2024-03-07T15:00:00Z)
+	// 2024-03-08: same day everywhere during [2024-03-08T05:00:00Z, 2024-03-08T15:00:00Z)
+}